@@ -1,16 +1,33 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"go-audit/internal/analyzer"
+	"go-audit/internal/rules"
 	"go-audit/pkg/config"
 	"go-audit/pkg/report"
 )
@@ -20,39 +37,214 @@ var (
 	Version = "dev"
 )
 
+// Коды завершения команды
+const (
+	// exitOK анализ выполнен, проблем не найдено или они не приводят к ошибке
+	exitOK = 0
+	// exitError ошибка выполнения: некорректные флаги, конфигурация, чтение файлов или запись отчета
+	exitError = 1
+	// exitIssues найдены проблемы не ниже порога -fail-on или с CWE из -fail-on-cwe
+	exitIssues = 2
+)
+
+// stdinFileName имя синтетического файла для кода, переданного через -code или -code-file
+const stdinFileName = "stdin.go"
+
 func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run выполняет команду с указанными аргументами и возвращает код завершения
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 	// Настройка логгера
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: stderr, TimeFormat: time.RFC3339})
 
 	// Парсинг аргументов командной строки
-	configFile := flag.String("config", "", "путь к файлу конфигурации")
-	outputFormat := flag.String("format", "text", "формат вывода (text, json)")
-	outputFile := flag.String("output", "", "выходной файл (по умолчанию: stdout)")
-	recursive := flag.Bool("recursive", false, "рекурсивное сканирование директорий")
-	excludeDirs := flag.String("exclude", "", "список директорий для исключения через запятую")
-	verboseFlag := flag.Bool("verbose", false, "режим подробного вывода")
-	versionFlag := flag.Bool("version", false, "вывести версию и выйти")
-	flag.Parse()
+	flags := flag.NewFlagSet("goaudit", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { printDefaults(flags) }
+	configFile := flags.String("config", "", "путь к файлу конфигурации")
+	outputFormat := flags.String("format", "text", "формат вывода (text, json, sarif, html, junit, csv, jsonl), несколько форматов через запятую")
+	outputFile := flags.String("output", "", "выходной файл (по умолчанию: stdout), для нескольких форматов список через запятую (- для stdout)")
+	outputDir := flags.String("output-dir", "", "директория для отдельных отчетов по каждому файлу с проблемами (<dir>/<путь>.<расширение>)")
+	recursive := flags.Bool("recursive", false, "рекурсивное сканирование директорий")
+	excludeDirs := flags.String("exclude", "", "список директорий для исключения через запятую")
+	filesFrom := flags.String("files-from", "", "файл со списком анализируемых файлов по одному в строке (- для stdin)")
+	diffFile := flags.String("diff", "", "файл с unified diff (- для stdin): анализируются измененные файлы, в отчет попадают только проблемы в добавленных строках")
+	code := flags.String("code", "", "исходный код Go для анализа (анализируется как stdin.go)")
+	codeFile := flags.String("code-file", "", "файл с исходным кодом Go для анализа как stdin.go (- для stdin)")
+	fixesFile := flags.String("fixes", "", "записать в файл JSON с предлагаемыми исправлениями найденных проблем")
+	applyFixesFlag := flags.Bool("apply-fixes", false, "применить к файлам однозначные исправления найденных проблем и вывести в stderr список изменений")
+	showSuppressed := flags.Bool("show-suppressed", false, "вывести в stderr статистику и список подавленных проблем")
+	strict := flags.Bool("strict", false, "учитывать проблемы, подавленные директивами goaudit:ignore и goaudit:disable, и выводить их список в stderr")
+	showUnused := flags.Bool("show-unused-suppressions", false, "вывести в stderr директивы goaudit:ignore, не подавившие ни одной проблемы")
+	minSeverity := flags.String("min-severity", "", "минимальный уровень серьезности проблем в отчете (CRITICAL, HIGH, MEDIUM, LOW, INFO)")
+	failOn := flags.String("fail-on", "", "минимальный уровень серьезности проблем, при котором команда завершается с ошибкой (по умолчанию: любой)")
+	ruleCoverage := flags.Bool("rule-coverage", false, "вывести в stderr количество срабатываний каждого правила")
+	failOnCWE := flags.String("fail-on-cwe", "", "список CWE через запятую, при наличии которых команда завершается с ошибкой")
+	baselineFile := flags.String("baseline", "", "файл базовой линии: известные проблемы из него не попадают в отчет")
+	updateBaseline := flags.Bool("update-baseline", false, "записать все найденные проблемы в файл -baseline и выйти")
+	noFail := flags.Bool("no-fail", false, "завершаться с кодом 0 при найденных проблемах (ошибки выполнения по-прежнему дают код 1)")
+	check := flags.Bool("check", false, "не выводить отчет и логи, только код завершения (2 при наличии проблем)")
+	enableRules := flags.String("rules", "", "список правил через запятую, которые нужно запускать (заменяет enabledRules из конфигурации)")
+	skipRules := flags.String("skip-rules", "", "список правил через запятую, которые нужно пропустить")
+	demoteRules := flags.String("demote-rules", "", "список правил через запятую, проблемы которых понижаются до INFO без отключения (дополняет demotedRules из конфигурации)")
+	excludeRuleInPath := flags.String("exclude-rule-in-path", "", "отключить правила для файлов по шаблону пути: шаблон=ПРАВИЛО,ПРАВИЛО; несколько шаблонов через точку с запятой")
+	rulesAddedSince := flags.String("rules-added-since", "", "запускать только правила, появившиеся в указанной версии или позже")
+	concurrency := flags.Int("concurrency", 0, "максимальное количество одновременно анализируемых файлов или пакетов (по умолчанию: concurrency из конфигурации или число процессоров)")
+	useCache := flags.Bool("cache", false, "использовать кэш результатов анализа неизмененных файлов")
+	cacheDir := flags.String("cache-dir", "", "директория кэша результатов анализа, включает -cache (по умолчанию: go-audit в пользовательском кэше ОС)")
+	progressEvery := flags.Int("progress", 0, "выводить в лог прогресс анализа через каждые N файлов (0 отключает вывод)")
+	initFlag := flags.Bool("init", false, "записать конфигурацию по умолчанию в .gosecheck.json (или в файл -config) и выйти")
+	force := flags.Bool("force", false, "перезаписать существующий файл конфигурации при -init")
+	listRulesFlag := flags.Bool("list-rules", false, "вывести список правил (text или json в зависимости от -format) и выйти")
+	verboseFlag := flags.Bool("verbose", false, "режим подробного вывода")
+	versionFlag := flags.Bool("version", false, "вывести версию и выйти")
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
 
 	// Вывод версии при запросе
 	if *versionFlag {
-		fmt.Printf("Go-audit v%s\n", Version)
-		os.Exit(0)
+		fmt.Fprintf(stdout, "Go-audit v%s\n", Version)
+		return exitOK
+	}
+
+	// Вывод списка правил без анализа
+	if *listRulesFlag {
+		if err := listRules(stdout, analyzer.New(nil).Rules(), *outputFormat); err != nil {
+			fmt.Fprintf(stderr, "Ошибка вывода списка правил: %v\n", err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	// Создание файла конфигурации по умолчанию без анализа
+	if *initFlag {
+		configPath := *configFile
+		if configPath == "" {
+			configPath = config.ConfigFileName
+		}
+		if err := initConfig(configPath, *force); err != nil {
+			fmt.Fprintf(stderr, "Ошибка создания конфигурации: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintf(stderr, "Создан файл конфигурации %s\n", configPath)
+		return exitOK
 	}
 
 	// Установка уровня логирования
-	if *verboseFlag {
+	switch {
+	case *check:
+		// В режиме проверки выводятся только операционные ошибки
+		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
+	case *verboseFlag:
 		zerolog.SetGlobalLevel(zerolog.DebugLevel)
 	}
 
-	args := flag.Args()
-	if len(args) == 0 {
+	// Чтение исходного кода, переданного напрямую
+	var source []byte
+	switch {
+	case *code != "":
+		source = []byte(*code)
+	case *codeFile == "-":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			log.Error().Err(err).Msg("Ошибка чтения кода из stdin")
+			return exitError
+		}
+		source = data
+	case *codeFile != "":
+		data, err := os.ReadFile(*codeFile)
+		if err != nil {
+			log.Error().Err(err).Str("file", *codeFile).Msg("Ошибка чтения файла с кодом")
+			return exitError
+		}
+		source = data
+	}
+
+	if *applyFixesFlag && source != nil {
+		log.Error().Msg("Флаг -apply-fixes нельзя использовать с -code и -code-file")
+		return exitError
+	}
+
+	if countStdinReaders(*filesFrom, *codeFile, *diffFile) > 1 {
+		log.Error().Msg("Только один из флагов -files-from, -code-file и -diff может читать stdin")
+		return exitError
+	}
+
+	// Список файлов, например из git diff --name-only, дополняет позиционные аргументы
+	var listedFiles []string
+	if *filesFrom != "" {
+		list, err := readFilesFrom(*filesFrom, stdin)
+		if err != nil {
+			log.Error().Err(err).Str("file", *filesFrom).Msg("Ошибка чтения списка файлов")
+			return exitError
+		}
+		listedFiles = filterListedFiles(list, strings.Split(*excludeDirs, ","))
+	}
+
+	// Измененные строки из diff: файлы diff дополняют позиционные аргументы
+	var diffLines changedLines
+	if *diffFile != "" {
+		lines, err := readDiff(*diffFile, stdin)
+		if err != nil {
+			log.Error().Err(err).Str("file", *diffFile).Msg("Ошибка чтения diff")
+			return exitError
+		}
+		diffLines = lines
+		listedFiles = mergeFiles(listedFiles, filterListedFiles(diffLines.files(), strings.Split(*excludeDirs, ",")))
+	}
+
+	targets := flags.Args()
+	if len(targets) == 0 && source == nil && *filesFrom == "" && *diffFile == "" {
 		log.Error().Msg("Не указаны целевые файлы или директории")
-		fmt.Println("Использование: gosecheck [опции] <file.go|directory>...")
-		flag.PrintDefaults()
-		os.Exit(1)
+		fmt.Fprintln(stdout, "Использование: gosecheck [опции] <file.go|directory|./...>...")
+		printDefaults(flags)
+		return exitError
+	}
+
+	// Порог серьезности для отчета
+	var threshold report.Severity
+	if *minSeverity != "" {
+		severity, err := report.ParseSeverity(*minSeverity)
+		if err != nil {
+			log.Error().Err(err).Msg("Некорректное значение -min-severity")
+			return exitError
+		}
+		threshold = severity
+	}
+
+	// Порог серьезности для кода завершения
+	var failThreshold report.Severity
+	if *failOn != "" {
+		severity, err := report.ParseSeverity(*failOn)
+		if err != nil {
+			log.Error().Err(err).Msg("Некорректное значение -fail-on")
+			return exitError
+		}
+		failThreshold = severity
+	}
+
+	// Форматы отчета и файлы, в которые они записываются
+	var reportTargets []reportTarget
+	if *outputDir != "" {
+		if *outputFile != "" {
+			log.Error().Msg("Флаги -output и -output-dir нельзя использовать одновременно")
+			return exitError
+		}
+		// Пути отчетов по файлам определяются при записи, каждый формат дает свой файл
+		for _, format := range strings.Split(*outputFormat, ",") {
+			reportTargets = append(reportTargets, reportTarget{format: strings.TrimSpace(format)})
+		}
+	} else {
+		var err error
+		if reportTargets, err = parseReportTargets(*outputFormat, *outputFile); err != nil {
+			log.Error().Err(err).Msg("Некорректные значения -format и -output")
+			return exitError
+		}
 	}
 
 	// Загрузка конфигурации
@@ -60,17 +252,839 @@ func main() {
 	cfg, err := config.Load(*configFile)
 	if err != nil {
 		log.Error().Err(err).Msg("Ошибка загрузки конфигурации")
-		os.Exit(1)
+		return exitError
+	}
+
+	// Опечатка в идентификаторе правила иначе молча не включает и не отключает ничего
+	if err := cfg.ValidateRuleIDs(analyzer.RuleIDs()); err != nil {
+		log.Error().Err(err).Msg("Некорректная конфигурация")
+		return exitError
+	}
+
+	if *concurrency != 0 {
+		cfg.Concurrency = *concurrency
+	}
+
+	if *failOnCWE != "" {
+		cfg.FailOnCWE = strings.Split(*failOnCWE, ",")
+	}
+
+	// Списки правил из командной строки имеют приоритет над конфигурацией
+	enabledIDs, skippedIDs := splitRuleIDs(*enableRules), splitRuleIDs(*skipRules)
+	if err := validateRuleIDs(append(enabledIDs, skippedIDs...), analyzer.RuleIDs()); err != nil {
+		log.Error().Err(err).Msg("Некорректный список правил")
+		return exitError
+	}
+	cfg.OverrideRules(enabledIDs, skippedIDs)
+
+	if demotedIDs := splitRuleIDs(*demoteRules); len(demotedIDs) > 0 {
+		if err := validateRuleIDs(demotedIDs, analyzer.RuleIDs()); err != nil {
+			log.Error().Err(err).Msg("Некорректное значение -demote-rules")
+			return exitError
+		}
+		cfg.DemotedRules = append(cfg.DemotedRules, demotedIDs...)
+	}
+
+	if *excludeRuleInPath != "" {
+		overrides, err := parsePathRuleOverrides(*excludeRuleInPath)
+		if err == nil {
+			var ids []string
+			for _, override := range overrides {
+				ids = append(ids, override.DisableRules...)
+			}
+			err = validateRuleIDs(ids, analyzer.RuleIDs())
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Некорректное значение -exclude-rule-in-path")
+			return exitError
+		}
+		cfg.PathRuleOverrides = append(cfg.PathRuleOverrides, overrides...)
+	}
+
+	if *updateBaseline && *baselineFile == "" {
+		log.Error().Msg("Для -update-baseline необходимо указать -baseline")
+		return exitError
 	}
 
 	// Инициализация анализатора
-	a := analyzer.New(cfg)
+	var opts []analyzer.Option
+	if *rulesAddedSince != "" {
+		opts = append(opts, analyzer.WithRulesAddedSince(*rulesAddedSince))
+	}
+	if *progressEvery > 0 {
+		opts = append(opts, analyzer.WithProgress(*progressEvery))
+	}
+	if *baselineFile != "" && !*updateBaseline {
+		baseline, err := report.LoadBaseline(*baselineFile)
+		if err != nil {
+			log.Error().Err(err).Str("file", *baselineFile).Msg("Ошибка загрузки базовой линии")
+			return exitError
+		}
+		opts = append(opts, analyzer.WithBaseline(baseline))
+	}
+	if *useCache || *cacheDir != "" {
+		dir := *cacheDir
+		if dir == "" {
+			var err error
+			if dir, err = analyzer.DefaultCacheDir(); err != nil {
+				log.Error().Err(err).Msg("Не удалось определить директорию кэша, укажите -cache-dir")
+				return exitError
+			}
+		}
+		opts = append(opts, analyzer.WithCache(dir, Version))
+	}
+	a := analyzer.New(cfg, opts...)
+
+	// Сведения о запуске для отчетов
+	meta := report.Metadata{ToolVersion: Version, TargetPaths: targets}
+	started := time.Now()
+
+	var results []report.Issue
+	if source != nil {
+		meta.TargetPaths = []string{stdinFileName}
+		// Анализ кода, переданного напрямую, без применения исключений
+		results, err = a.AnalyzeSource(stdinFileName, source)
+		if err != nil {
+			log.Error().Err(err).Msg("Ошибка анализа переданного кода")
+			return exitError
+		}
+		meta.AnalyzedFiles = []string{stdinFileName}
+	} else {
+		// Поиск всех Go файлов для анализа
+		files := mergeFiles(collectFiles(targets, *recursive, strings.Split(*excludeDirs, ",")), listedFiles)
+		log.Info().Int("count", len(files)).Msg("Найдено файлов для анализа")
+
+		// Запуск анализа: файлы одной директории проверяются вместе как пакет.
+		// SIGINT отменяет запуск новых пакетов, начатые пакеты дорабатывают.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		results, err = a.AnalyzePackagesContext(ctx, files)
+		stop()
+		if errors.Is(err, context.Canceled) {
+			log.Error().Msg("Анализ прерван")
+			return exitError
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Ошибка во время анализа")
+			return exitError
+		}
+		meta.AnalyzedFiles = analyzedFiles(files, cfg, a.AnalysisErrors())
+	}
+
+	meta.ScanDuration = time.Since(started)
+	meta.ScannedFiles, _ = a.FilesProcessed()
+
+	if hits, misses := a.CacheStats(); hits+misses > 0 {
+		log.Debug().Int("hits", hits).Int("misses", misses).Msg("Использование кэша")
+	}
+
+	// Обновление базовой линии: сохраняются все найденные проблемы, отчет не формируется
+	if *updateBaseline {
+		if err := report.WriteBaseline(*baselineFile, results); err != nil {
+			log.Error().Err(err).Str("file", *baselineFile).Msg("Ошибка записи базовой линии")
+			return exitError
+		}
+		log.Info().Str("file", *baselineFile).Int("issues", len(results)).Msg("Базовая линия обновлена")
+		return exitOK
+	}
+
+	// В строгом режиме проблемы, подавленные директивами в коде, возвращаются в отчет
+	// и влияют на код завершения, чтобы каждое подавление проходило ревью
+	var strictIssues []analyzer.SuppressedIssue
+	if *strict {
+		strictIssues = directiveSuppressions(a.SuppressedIssues())
+		for _, s := range strictIssues {
+			results = append(results, s.Issue)
+		}
+	}
+	// Проблемы, возвращенные в отчет режимом -strict, не считаются подавленными
+	meta.Suppressed = a.SuppressionStats().Total() - len(strictIssues)
+
+	// В режиме -diff остаются только проблемы в измененных строках
+	if diffLines != nil {
+		results = diffLines.filter(results)
+	}
+
+	// Проблемы с CWE из списка запрещенных проверяются независимо от серьезности
+	failedCWEs := matchedCWEs(results, cfg.FailOnCWE)
+
+	// Отбрасываем проблемы ниже порога серьезности
+	if threshold != "" {
+		results = report.FilterBySeverity(results, threshold)
+	}
+
+	exitCode := decideExitCode(results, exitOptions{
+		failOn:     failThreshold,
+		failedCWEs: failedCWEs,
+		noFail:     *noFail,
+	})
+
+	// В режиме проверки отчет не формируется
+	if *check {
+		return exitCode
+	}
+
+	// Генерация и запись отчетов: общий отчет или отдельные отчеты по файлам
+	if *outputDir != "" {
+		suppressed := suppressedByFile(a.SuppressedIssues(), *strict)
+		if err := writeFileReports(*outputDir, results, reportTargets, a.Rules(), cfg.ResolveScoreWeights(), &meta, suppressed); err != nil {
+			log.Error().Err(err).Str("dir", *outputDir).Msg("Ошибка записи отчетов по файлам")
+			return exitError
+		}
+	} else if err := writeReports(stdout, results, reportTargets, a.Rules(), cfg.ResolveScoreWeights(), &meta); err != nil {
+		log.Error().Err(err).Msg("Ошибка записи выходного файла")
+		return exitError
+	}
+
+	// Предлагаемые исправления записываются отдельно от отчета и не применяются
+	if *fixesFile != "" {
+		output := report.NewFixesReporter().Generate(results)
+		if err := os.WriteFile(*fixesFile, []byte(output), 0644); err != nil {
+			log.Error().Err(err).Str("file", *fixesFile).Msg("Ошибка записи исправлений")
+			return exitError
+		}
+		log.Info().Str("file", *fixesFile).Msg("Исправления записаны в файл")
+	}
+
+	if *applyFixesFlag {
+		printAppliedFixes(stderr, applyFixes(results))
+	}
+
+	if *showSuppressed {
+		printSuppressions(stderr, a.SuppressionStats(), a.SuppressedIssues())
+	}
+
+	if *strict {
+		printStrictSuppressions(stderr, strictIssues)
+	}
+
+	if *showUnused {
+		printUnusedSuppressions(stderr, a.UnusedSuppressions())
+	}
+
+	if *ruleCoverage {
+		if err := printRuleCoverage(stderr, a.RuleCoverage(results), *outputFormat); err != nil {
+			log.Error().Err(err).Msg("Ошибка вывода покрытия правил")
+			return exitError
+		}
+	}
+
+	if len(failedCWEs) > 0 {
+		log.Error().Strs("cwe", failedCWEs).Msg("Найдены проблемы с запрещенными CWE")
+	}
+
+	// Файлы с ошибками разбора не попадают в отчет, поэтому о них предупреждаем отдельно
+	if analysisErrors := a.AnalysisErrors(); len(analysisErrors) > 0 {
+		printAnalysisErrors(stderr, analysisErrors)
+	}
+
+	// Итоговая строка выводится в stderr, чтобы не смешиваться с отчетом в stdout
+	processed, _ := a.FilesProcessed()
+	printSummary(stderr, processed, results, meta.Suppressed)
+
+	return exitCode
+}
+
+// applyFixes применяет к файлам исправления, помеченные SafeToApply. Замены в файле выполняются
+// с конца, чтобы смещения предыдущих оставались верными; пересекающиеся исправления пропускаются.
+// Если файл после замен не разбирается, он остается без изменений. Возвращает проблемы с примененными исправлениями.
+func applyFixes(issues []report.Issue) []report.Issue {
+	byFile := make(map[string][]report.Issue)
+	var files []string
+	for _, issue := range issues {
+		if issue.SuggestedFix == nil || !issue.SuggestedFix.SafeToApply {
+			continue
+		}
+		if _, ok := byFile[issue.FilePath]; !ok {
+			files = append(files, issue.FilePath)
+		}
+		byFile[issue.FilePath] = append(byFile[issue.FilePath], issue)
+	}
+	sort.Strings(files)
+
+	var applied []report.Issue
+	for _, file := range files {
+		fileFixes, err := applyFileFixes(file, byFile[file])
+		if err != nil {
+			log.Warn().Err(err).Str("file", file).Msg("Исправления не применены")
+			continue
+		}
+		applied = append(applied, fileFixes...)
+	}
+	return applied
+}
+
+// applyFileFixes применяет исправления к одному файлу и записывает его, если пакет с исправленным файлом
+// проходит проверку типов без новых ошибок
+func applyFileFixes(path string, issues []report.Issue) ([]report.Issue, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := append([]byte(nil), original...)
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].SuggestedFix.StartOffset > issues[j].SuggestedFix.StartOffset
+	})
+
+	var applied []report.Issue
+	limit := len(content)
+	for _, issue := range issues {
+		fix := issue.SuggestedFix
+		if fix.StartOffset < 0 || fix.StartOffset > fix.EndOffset || fix.EndOffset > limit {
+			log.Debug().Str("file", path).Str("rule", issue.RuleID).Int("line", issue.Line).Msg("Исправление выходит за пределы файла или пересекается с другим, пропущено")
+			continue
+		}
+		content = append(content[:fix.StartOffset:fix.StartOffset], append([]byte(fix.Replacement), content[fix.EndOffset:]...)...)
+		limit = fix.StartOffset
+		applied = append(applied, issue)
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	content, err = removeUnusedImports(original, content)
+	if err != nil {
+		return nil, fmt.Errorf("файл не разбирается после исправлений: %w", err)
+	}
+	if err := checkFixedPackage(path, original, content); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, content, info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+
+	// Исправления выводятся в порядке расположения в файле
+	for i, j := 0, len(applied)-1; i < j; i, j = i+1, j-1 {
+		applied[i], applied[j] = applied[j], applied[i]
+	}
+	return applied, nil
+}
+
+// removeUnusedImports удаляет импорты, которые использовались в исходном файле, но после исправлений
+// больше не используются: замена последнего вызова ioutil оставляет неиспользуемый импорт io/ioutil
+func removeUnusedImports(original, content []byte) ([]byte, error) {
+	before, err := parser.ParseFile(token.NewFileSet(), "", original, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	after, err := parser.ParseFile(fset, "", content, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	usedBefore, usedAfter := usedPackageNames(before), usedPackageNames(after)
+
+	// Импорты удаляются с конца файла, чтобы смещения предыдущих оставались верными
+	for i := len(after.Imports) - 1; i >= 0; i-- {
+		spec := after.Imports[i]
+		name := importSpecName(spec)
+		if name == "_" || name == "." || !usedBefore[name] || usedAfter[name] {
+			continue
+		}
+
+		start, end := fset.Position(spec.Pos()).Offset, fset.Position(spec.End()).Offset
+		if decl := importDecl(after, spec); decl != nil && !decl.Lparen.IsValid() {
+			// Одиночный импорт удаляется вместе с ключевым словом import
+			start, end = fset.Position(decl.Pos()).Offset, fset.Position(decl.End()).Offset
+		}
+		// Удаляем всю строку вместе с отступом и переводом строки
+		for start > 0 && (content[start-1] == ' ' || content[start-1] == '\t') {
+			start--
+		}
+		if end < len(content) && content[end] == '\n' {
+			end++
+		}
+		content = append(content[:start:start], content[end:]...)
+	}
+	return content, nil
+}
+
+// usedPackageNames возвращает имена, используемые в файле как X в селекторах X.Sel
+func usedPackageNames(file *ast.File) map[string]bool {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return used
+}
+
+// importSpecName возвращает локальное имя импорта: псевдоним или последний элемент пути
+func importSpecName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	path, _ := strconv.Unquote(spec.Path.Value)
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// importDecl возвращает объявление import, содержащее спецификацию
+func importDecl(file *ast.File, spec *ast.ImportSpec) *ast.GenDecl {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		for _, s := range gen.Specs {
+			if s == spec {
+				return gen
+			}
+		}
+	}
+	return nil
+}
+
+// checkFixedPackage проверяет типы пакета файла до и после исправлений и возвращает ошибку,
+// если исправления добавили ошибки компиляции. Ошибки, которые были и до исправлений
+// (например, неразрешимые импорты), не учитываются.
+func checkFixedPackage(path string, original, content []byte) error {
+	before, err := packageTypeErrors(path, original)
+	if err != nil {
+		return err
+	}
+	after, err := packageTypeErrors(path, content)
+	if err != nil {
+		return err
+	}
+
+	for msg, count := range after {
+		if count > before[msg] {
+			return fmt.Errorf("исправления нарушают компиляцию: %s", msg)
+		}
+	}
+	return nil
+}
+
+// packageTypeErrors выполняет проверку типов пакета, в котором файл path заменен содержимым content,
+// и возвращает сообщения об ошибках без позиций с числом повторений
+func packageTypeErrors(path string, content []byte) (map[string]int, error) {
+	fset := token.NewFileSet()
+	target, err := parser.ParseFile(fset, path, content, 0)
+	if err != nil {
+		return nil, err
+	}
+	files := []*ast.File{target}
+
+	// Остальные файлы того же пакета в директории
+	matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.go"))
+	for _, match := range matches {
+		if same, err := sameFile(match, path); err != nil || same {
+			continue
+		}
+		file, err := parser.ParseFile(fset, match, nil, 0)
+		if err != nil || file.Name.Name != target.Name.Name {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	errs := make(map[string]int)
+	conf := types.Config{
+		Importer: importer.Default(),
+		Error: func(err error) {
+			if typeErr, ok := err.(types.Error); ok {
+				errs[typeErr.Msg]++
+				return
+			}
+			errs[err.Error()]++
+		},
+	}
+	_, _ = conf.Check(target.Name.Name, fset, files, nil)
+	return errs, nil
+}
+
+// sameFile проверяет, указывают ли пути на один файл
+func sameFile(a, b string) (bool, error) {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+	return os.SameFile(infoA, infoB), nil
+}
+
+// printAppliedFixes выводит список примененных исправлений
+func printAppliedFixes(w io.Writer, applied []report.Issue) {
+	fmt.Fprintf(w, "Применено исправлений: %d\n", len(applied))
+	for _, issue := range applied {
+		fmt.Fprintf(w, "  %s:%d %s: %s\n", issue.FilePath, issue.Line, issue.RuleID, issue.SuggestedFix.Description)
+	}
+}
+
+// reportTarget задает формат отчета и файл для записи, пустой путь означает stdout
+type reportTarget struct {
+	format string
+	path   string
+}
+
+// parseReportTargets сопоставляет списки форматов и выходных файлов через запятую.
+// Для одного формата файл необязателен, для нескольких количество файлов должно совпадать с количеством форматов.
+func parseReportTargets(formats, outputs string) ([]reportTarget, error) {
+	formatList := strings.Split(formats, ",")
+	var outputList []string
+	if outputs != "" {
+		outputList = strings.Split(outputs, ",")
+	}
+
+	if len(formatList) > 1 || len(outputList) > 1 {
+		if len(formatList) != len(outputList) {
+			return nil, fmt.Errorf("количество форматов (%d) не совпадает с количеством выходных файлов (%d)", len(formatList), len(outputList))
+		}
+	}
+
+	targets := make([]reportTarget, len(formatList))
+	stdoutUsed := false
+	for i, format := range formatList {
+		targets[i].format = strings.TrimSpace(format)
+		if i < len(outputList) {
+			if path := strings.TrimSpace(outputList[i]); path != "-" {
+				targets[i].path = path
+			}
+		}
+		if targets[i].path == "" {
+			if stdoutUsed {
+				return nil, fmt.Errorf("в stdout можно вывести только один формат")
+			}
+			stdoutUsed = true
+		}
+	}
+	return targets, nil
+}
+
+// newReporter создает генератор отчета для формата, неизвестный формат выводится как text.
+// weights задает веса серьезности для оценки риска в текстовом и JSON-отчетах,
+// meta — сведения о запуске для JSON- и текстового отчетов (nil — без метаданных).
+func newReporter(format string, ruleList []rules.Rule, weights map[report.Severity]int, meta *report.Metadata) report.Reporter {
+	switch format {
+	case "json":
+		reporter := report.NewJSONReporter().WithScoreWeights(weights)
+		if meta != nil {
+			reporter.WithMetadata(*meta)
+		}
+		return reporter
+	case "sarif":
+		return report.NewSARIFReporter(ruleInfos(ruleList)...)
+	case "html":
+		return report.NewHTMLReporter()
+	case "junit":
+		reporter := report.NewJUnitReporter()
+		if meta != nil {
+			reporter.WithMetadata(*meta)
+		}
+		return reporter
+	case "csv":
+		return report.NewCSVReporter()
+	case "jsonl":
+		return report.NewJSONLinesReporter()
+	default:
+		reporter := report.NewTextReporter().WithScoreWeights(weights)
+		if meta != nil {
+			reporter.WithMetadata(*meta)
+		}
+		return reporter
+	}
+}
+
+// writeReports формирует отчет в каждом из форматов и записывает его в stdout или файл
+func writeReports(stdout io.Writer, issues []report.Issue, targets []reportTarget, ruleList []rules.Rule, weights map[report.Severity]int, meta *report.Metadata) error {
+	for _, target := range targets {
+		output := newReporter(target.format, ruleList, weights, meta).Generate(issues)
+
+		if target.path == "" {
+			fmt.Fprintln(stdout, output)
+			continue
+		}
+		if err := os.WriteFile(target.path, []byte(output), 0644); err != nil {
+			return err
+		}
+		log.Info().Str("file", target.path).Str("format", target.format).Msg("Отчет записан в файл")
+	}
+	return nil
+}
+
+// reportExtensions расширения файлов отчетов по форматам для -output-dir
+var reportExtensions = map[string]string{
+	"text":  "txt",
+	"json":  "json",
+	"sarif": "sarif",
+	"html":  "html",
+	"junit": "xml",
+	"csv":   "csv",
+	"jsonl": "jsonl",
+}
+
+// writeFileReports записывает отдельный отчет для каждого файла с проблемами в дерево директорий outputDir,
+// повторяющее пути анализируемых файлов: <outputDir>/<путь>.<расширение>. Файлы без проблем пропускаются.
+// suppressed содержит количество подавленных проблем по файлам для сведений о запуске в отчете файла.
+func writeFileReports(outputDir string, issues []report.Issue, targets []reportTarget, ruleList []rules.Rule, weights map[report.Severity]int, meta *report.Metadata, suppressed map[string]int) error {
+	// Группируем проблемы по файлам, сохраняя порядок первого появления
+	byFile := make(map[string][]report.Issue)
+	var files []string
+	for _, issue := range issues {
+		if _, ok := byFile[issue.FilePath]; !ok {
+			files = append(files, issue.FilePath)
+		}
+		byFile[issue.FilePath] = append(byFile[issue.FilePath], issue)
+	}
+
+	for _, file := range files {
+		relPath := reportRelPath(file)
+		for _, target := range targets {
+			ext, ok := reportExtensions[target.format]
+			if !ok {
+				ext = reportExtensions["text"]
+			}
+			path := filepath.Join(outputDir, relPath+"."+ext)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			output := newReporter(target.format, ruleList, weights, fileMeta(meta, file, suppressed[file])).Generate(byFile[file])
+			if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Info().Str("dir", outputDir).Int("files", len(files)).Msg("Отчеты по файлам записаны")
+	return nil
+}
+
+// fileMeta возвращает сведения о запуске для отчета по одному файлу: счетчики относятся только к нему,
+// а не ко всему запуску
+func fileMeta(meta *report.Metadata, file string, suppressed int) *report.Metadata {
+	if meta == nil {
+		return nil
+	}
+	m := *meta
+	m.ScannedFiles = 1
+	m.AnalyzedFiles = []string{file}
+	m.Suppressed = suppressed
+	return &m
+}
+
+// suppressedByFile возвращает количество подавленных проблем по файлам. В строгом режиме
+// подавления директивами не учитываются, так как эти проблемы возвращены в отчет.
+func suppressedByFile(suppressed []analyzer.SuppressedIssue, strict bool) map[string]int {
+	counts := make(map[string]int)
+	for _, s := range suppressed {
+		if strict && (s.Mechanism == analyzer.SuppressionInline || s.Mechanism == analyzer.SuppressionFile) {
+			continue
+		}
+		counts[s.Issue.FilePath]++
+	}
+	return counts
+}
+
+// reportRelPath возвращает путь файла внутри директории отчетов: абсолютные пути берутся относительно
+// текущей директории, а выходящие за ее пределы элементы .. и корень отбрасываются
+func reportRelPath(filePath string) string {
+	path := filepath.Clean(filePath)
+	if filepath.IsAbs(path) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, path); err == nil {
+				path = rel
+			}
+		}
+	}
+
+	path = strings.TrimPrefix(path, filepath.VolumeName(path))
+	for {
+		trimmed := strings.TrimPrefix(strings.TrimPrefix(path, string(filepath.Separator)), ".."+string(filepath.Separator))
+		if trimmed == path {
+			return path
+		}
+		path = trimmed
+	}
+}
+
+// exitOptions задает условия завершения с кодом exitIssues
+type exitOptions struct {
+	// Порог серьезности -fail-on, пустой означает любую проблему
+	failOn report.Severity
+	// Найденные CWE из списка -fail-on-cwe
+	failedCWEs []string
+	// Не завершаться с ошибкой при найденных проблемах
+	noFail bool
+}
+
+// decideExitCode определяет код завершения по найденным проблемам
+func decideExitCode(issues []report.Issue, opts exitOptions) int {
+	if opts.noFail {
+		return exitOK
+	}
+	if shouldFail(issues, opts.failOn) || len(opts.failedCWEs) > 0 {
+		return exitIssues
+	}
+	return exitOK
+}
+
+// shouldFail определяет, должна ли команда завершиться с ошибкой из-за найденных проблем.
+// Пустой порог означает завершение с ошибкой при любой проблеме.
+func shouldFail(issues []report.Issue, threshold report.Severity) bool {
+	if threshold == "" {
+		return len(issues) > 0
+	}
+	return len(report.FilterBySeverity(issues, threshold)) > 0
+}
+
+// printSummary выводит итоговую строку с количеством проверенных файлов, проблем по уровням серьезности
+// и подавленных проблем
+func printSummary(w io.Writer, files int, issues []report.Issue, suppressed int) {
+	counts := make(map[report.Severity]int)
+	for _, issue := range issues {
+		counts[issue.Severity]++
+	}
+
+	var parts []string
+	for _, severity := range []report.Severity{report.SeverityCritical, report.SeverityHigh, report.SeverityMedium, report.SeverityLow, report.SeverityInfo} {
+		parts = append(parts, fmt.Sprintf("%s: %d", severity, counts[severity]))
+	}
+	fmt.Fprintf(w, "Проверено файлов: %d, найдено проблем: %d (%s), подавлено: %d\n",
+		files, len(issues), strings.Join(parts, ", "), suppressed)
+}
+
+// analyzedFiles возвращает файлы, переданные на анализ, без исключенных конфигурацией
+// и пропущенных из-за ошибок чтения или разбора
+func analyzedFiles(files []string, cfg *config.Config, errs []analyzer.AnalysisError) []string {
+	failed := make(map[string]bool, len(errs))
+	for _, e := range errs {
+		failed[e.FilePath] = true
+	}
+
+	var result []string
+	for _, file := range files {
+		if failed[file] || cfg.ShouldExclude(file) {
+			continue
+		}
+		result = append(result, file)
+	}
+	return result
+}
+
+// printAnalysisErrors выводит количество файлов, пропущенных из-за ошибок чтения или разбора, и сами ошибки
+func printAnalysisErrors(w io.Writer, errs []analyzer.AnalysisError) {
+	fmt.Fprintf(w, "Предупреждение: пропущено файлов из-за ошибок: %d\n", len(errs))
+	// Ошибки чтения и разбора уже содержат путь к файлу
+	for _, e := range errs {
+		fmt.Fprintf(w, "  %v\n", e.Err)
+	}
+}
+
+// ruleInfos возвращает метаданные правил для форматов отчетов
+func ruleInfos(ruleList []rules.Rule) []report.RuleInfo {
+	infos := make([]report.RuleInfo, 0, len(ruleList))
+	for _, rule := range ruleList {
+		info := report.RuleInfo{ID: rule.ID(), Description: rule.Description()}
+		if guided, ok := rule.(interface{ Remediation() string }); ok {
+			info.Remediation = guided.Remediation()
+		}
+		if referenced, ok := rule.(interface{ References() []string }); ok {
+			info.References = referenced.References()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// printRuleCoverage выводит количество срабатываний правил в текстовом или JSON-формате
+func printRuleCoverage(w io.Writer, coverage []analyzer.RuleCoverage, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(coverage, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	fmt.Fprintln(w, "Покрытие правил:")
+	for _, rule := range coverage {
+		fmt.Fprintf(w, "  %s: %d\n", rule.RuleID, rule.Hits)
+	}
+	return nil
+}
+
+// matchedCWEs возвращает CWE из списка, к которым относится хотя бы одна проблема
+func matchedCWEs(issues []report.Issue, cwes []string) []string {
+	found := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.CWE != "" {
+			found[normalizeCWE(issue.CWE)] = true
+		}
+	}
+
+	var matched []string
+	for _, cwe := range cwes {
+		cwe = normalizeCWE(cwe)
+		if cwe != "" && found[cwe] {
+			matched = append(matched, cwe)
+			delete(found, cwe)
+		}
+	}
+	return matched
+}
+
+// normalizeCWE приводит идентификатор CWE к виду CWE-89 (допускаются 89 и cwe-89)
+func normalizeCWE(cwe string) string {
+	cwe = strings.ToUpper(strings.TrimSpace(cwe))
+	if cwe == "" || strings.HasPrefix(cwe, "CWE-") {
+		return cwe
+	}
+	return "CWE-" + cwe
+}
 
-	// Поиск всех Go файлов для анализа
+// hiddenFlags содержит служебные флаги для сопровождающих, не выводимые в справке
+var hiddenFlags = map[string]bool{
+	"rules-added-since": true,
+}
+
+// printDefaults выводит справку по флагам, пропуская служебные
+func printDefaults(flags *flag.FlagSet) {
+	visible := flag.NewFlagSet(flags.Name(), flag.ContinueOnError)
+	visible.SetOutput(flags.Output())
+	flags.VisitAll(func(f *flag.Flag) {
+		if !hiddenFlags[f.Name] {
+			visible.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	visible.PrintDefaults()
+}
+
+// collectFiles находит все Go файлы для анализа в указанных путях
+func collectFiles(targets []string, recursive bool, excludeDirsList []string) []string {
 	var files []string
-	excludeDirsList := strings.Split(*excludeDirs, ",")
-	for _, arg := range args {
+	for _, arg := range targets {
+		// Шаблоны пакетов ./... и path/... раскрываются, как в go vet
+		if root, ok := strings.CutSuffix(filepath.ToSlash(arg), "/..."); ok {
+			dir, err := resolvePackageDir(root)
+			if err != nil {
+				log.Error().Err(err).Str("pattern", arg).Msg("Ошибка разрешения шаблона пакетов")
+				continue
+			}
+			files = append(files, collectPatternFiles(dir, excludeDirsList)...)
+			continue
+		}
+
 		info, err := os.Stat(arg)
+		if err != nil && isImportPath(arg) {
+			// Пакет модуля может быть указан путем импорта
+			if dir, resolveErr := resolvePackageDir(arg); resolveErr == nil {
+				arg = dir
+				info, err = os.Stat(dir)
+			}
+		}
 		if err != nil {
 			log.Error().Err(err).Str("path", arg).Msg("Ошибка доступа к файлу/директории")
 			continue
@@ -84,7 +1098,7 @@ func main() {
 		}
 
 		// Это директория, находим все Go файлы
-		if *recursive {
+		if recursive {
 			err = filepath.Walk(arg, func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					return err
@@ -128,40 +1142,473 @@ func main() {
 		}
 	}
 
-	log.Info().Int("count", len(files)).Msg("Найдено файлов для анализа")
+	return files
+}
+
+// collectPatternFiles находит Go файлы пакетов шаблона root/.... Как и go vet, пропускает директории
+// с именами на . и _, testdata и вложенные модули со своим go.mod.
+func collectPatternFiles(root string, excludeDirsList []string) []string {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			if strings.HasSuffix(path, ".go") {
+				files = append(files, path)
+			}
+			return nil
+		}
+		if path == root {
+			return nil
+		}
+
+		name := d.Name()
+		if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" {
+			return filepath.SkipDir
+		}
+		for _, excludeDir := range excludeDirsList {
+			if excludeDir != "" && name == excludeDir {
+				return filepath.SkipDir
+			}
+		}
+		if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("path", root).Msg("Ошибка при сканировании директории")
+	}
+	return files
+}
+
+// isImportPath проверяет, может ли аргумент быть путем импорта, а не путем в файловой системе
+func isImportPath(arg string) bool {
+	return arg != "" && !strings.HasPrefix(arg, ".") && !filepath.IsAbs(arg) && !strings.HasSuffix(arg, ".go")
+}
+
+// resolvePackageDir возвращает директорию пакета по пути в файловой системе (., ./cmd, /abs/path)
+// или по пути импорта пакета модуля, содержащего текущую директорию
+func resolvePackageDir(path string) (string, error) {
+	if !isImportPath(path) {
+		return filepath.FromSlash(path), nil
+	}
+	// Существующая директория имеет приоритет над путем импорта
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return path, nil
+	}
+
+	moduleRoot, modulePath, err := findModule()
+	if err != nil {
+		return "", err
+	}
+	if path == modulePath {
+		return moduleRoot, nil
+	}
+	if rest, ok := strings.CutPrefix(path, modulePath+"/"); ok {
+		return filepath.Join(moduleRoot, filepath.FromSlash(rest)), nil
+	}
+	return "", fmt.Errorf("пакет %s не входит в модуль %s", path, modulePath)
+}
 
-	// Запуск анализа
-	results, err := a.AnalyzeFiles(files)
+// findModule находит корень модуля Go, содержащего текущую директорию, и путь модуля из go.mod
+func findModule() (root, modulePath string, err error) {
+	dir, err := os.Getwd()
 	if err != nil {
-		log.Error().Err(err).Msg("Ошибка во время анализа")
-		os.Exit(1)
+		return "", "", err
 	}
 
-	// Генерация отчета
-	var r report.Reporter
-	switch *outputFormat {
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if path, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					return dir, strings.Trim(strings.TrimSpace(path), `"`), nil
+				}
+			}
+			return "", "", fmt.Errorf("в %s не указан путь модуля", filepath.Join(dir, "go.mod"))
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", errors.New("файл go.mod не найден в текущей директории и ее родителях")
+		}
+		dir = parent
+	}
+}
+
+// initConfig записывает конфигурацию по умолчанию в configPath.
+// Существующий файл перезаписывается только при force.
+func initConfig(configPath string, force bool) error {
+	if !force {
+		if _, err := os.Stat(configPath); err == nil {
+			return fmt.Errorf("файл %s уже существует, используйте -force для перезаписи", configPath)
+		}
+	}
+	// Число процессоров зависит от машины, на которой создан файл: без concurrency
+	// в конфигурации каждый запуск использует число процессоров своей машины
+	cfg := config.DefaultConfig()
+	cfg.Concurrency = 0
+	return cfg.Save(configPath)
+}
+
+// ruleInfo описание правила для вывода -list-rules
+type ruleInfo struct {
+	ID          string          `json:"id"`
+	Severity    report.Severity `json:"severity"`
+	CWE         string          `json:"cwe,omitempty"`
+	Description string          `json:"description"`
+}
+
+// listRules выводит идентификатор, уровень серьезности, CWE и описание каждого правила
+// в виде таблицы или JSON
+func listRules(w io.Writer, ruleSet []rules.Rule, format string) error {
+	infos := make([]ruleInfo, 0, len(ruleSet))
+	for _, rule := range ruleSet {
+		info := ruleInfo{ID: rule.ID(), Severity: rule.Severity(), Description: rule.Description()}
+		if withCWE, ok := rule.(interface{ CWE() string }); ok {
+			info.CWE = withCWE.CWE()
+		}
+		infos = append(infos, info)
+	}
+
+	switch format {
 	case "json":
-		r = report.NewJSONReporter()
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(infos)
+	case "text":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tУРОВЕНЬ\tCWE\tОПИСАНИЕ")
+		for _, info := range infos {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", info.ID, info.Severity, info.CWE, info.Description)
+		}
+		return tw.Flush()
 	default:
-		r = report.NewTextReporter()
+		return fmt.Errorf("формат %q не поддерживается для -list-rules, используйте text или json", format)
 	}
+}
 
-	output := r.Generate(results)
+// splitRuleIDs разбирает список идентификаторов правил через запятую
+func splitRuleIDs(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
 
-	// Запись выходных данных
-	if *outputFile == "" {
-		fmt.Println(output)
-	} else {
-		err = os.WriteFile(*outputFile, []byte(output), 0644)
+// parsePathRuleOverrides разбирает значение -exclude-rule-in-path вида "cmd/**=SEC006,SEC004;*_mock.go=SEC002"
+func parsePathRuleOverrides(value string) ([]config.PathRuleOverride, error) {
+	var overrides []config.PathRuleOverride
+	for _, mapping := range strings.Split(value, ";") {
+		if mapping = strings.TrimSpace(mapping); mapping == "" {
+			continue
+		}
+		glob, ids, ok := strings.Cut(mapping, "=")
+		glob = strings.TrimSpace(glob)
+		if !ok || glob == "" {
+			return nil, fmt.Errorf("ожидался формат шаблон=ПРАВИЛО,ПРАВИЛО: %q", mapping)
+		}
+		ruleIDs := splitRuleIDs(ids)
+		if len(ruleIDs) == 0 {
+			return nil, fmt.Errorf("не указаны правила для шаблона %q", glob)
+		}
+		overrides = append(overrides, config.PathRuleOverride{PathGlob: glob, DisableRules: ruleIDs})
+	}
+	return overrides, nil
+}
+
+// validateRuleIDs проверяет, что все идентификаторы относятся к известным правилам
+func validateRuleIDs(ids, known []string) error {
+	knownSet := make(map[string]bool, len(known))
+	for _, id := range known {
+		knownSet[id] = true
+	}
+
+	var unknown []string
+	for _, id := range ids {
+		if !knownSet[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("неизвестные правила: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// readFilesFrom читает список файлов из файла или stdin, если path равен "-"
+func readFilesFrom(path string, stdin io.Reader) ([]string, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return readFileList(r)
+}
+
+// countStdinReaders возвращает количество флагов, читающих stdin (значение -)
+func countStdinReaders(values ...string) int {
+	count := 0
+	for _, value := range values {
+		if value == "-" {
+			count++
+		}
+	}
+	return count
+}
+
+// lineRange диапазон строк файла от Start до End включительно
+type lineRange struct {
+	Start, End int
+}
+
+// changedLines добавленные и измененные строки по файлам из unified diff
+type changedLines map[string][]lineRange
+
+// readDiff читает unified diff из файла или stdin (-)
+func readDiff(path string, stdin io.Reader) (changedLines, error) {
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
 		if err != nil {
-			log.Error().Err(err).Str("file", *outputFile).Msg("Ошибка записи выходного файла")
-			os.Exit(1)
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return parseDiff(r)
+}
+
+// hunkHeaderRegex соответствует заголовку фрагмента diff: @@ -a,b +c,d @@
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// parseDiff разбирает unified diff (git diff или diff -u) и возвращает диапазоны добавленных строк.
+// Заголовки файлов распознаются только вне фрагментов: добавленная строка "++ x" внутри фрагмента
+// выглядит как "+++ x" и не должна считаться новым файлом.
+func parseDiff(r io.Reader) (changedLines, error) {
+	lines := make(changedLines)
+	var file string
+	// Номер следующей строки новой версии файла
+	newLine := 0
+	// Количество еще не прочитанных строк старой и новой версии во фрагменте; оба 0 вне фрагмента
+	oldLeft, newLeft := 0, 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				if file != "" {
+					lines.add(file, newLine)
+				}
+				newLine++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, " "), line == "":
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+			// Строка "\ No newline at end of file" не относится ни к одной из версий
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			// Путь может завершаться меткой времени после табуляции: +++ file.go\t2024-01-01
+			path, _, _ := strings.Cut(strings.TrimPrefix(line, "+++ "), "\t")
+			file = ""
+			if path != "/dev/null" {
+				file = filepath.Clean(filepath.FromSlash(strings.TrimPrefix(path, "b/")))
+				if _, ok := lines[file]; !ok {
+					lines[file] = nil
+				}
+			}
+		case strings.HasPrefix(line, "@@"):
+			match := hunkHeaderRegex.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("некорректный заголовок фрагмента diff: %q", line)
+			}
+			oldLeft, newLeft = hunkCount(match[1]), hunkCount(match[3])
+			newLine, _ = strconv.Atoi(match[2])
+		}
+		// Остальные строки вне фрагментов: diff --git, index, --- и другие заголовки
+	}
+	return lines, scanner.Err()
+}
+
+// hunkCount возвращает количество строк из заголовка фрагмента; без явного значения это 1
+func hunkCount(s string) int {
+	if s == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// add добавляет строку к диапазонам файла, продлевая последний диапазон для идущих подряд строк
+func (c changedLines) add(file string, line int) {
+	ranges := c[file]
+	if n := len(ranges); n > 0 && ranges[n-1].End == line-1 {
+		ranges[n-1].End = line
+		return
+	}
+	c[file] = append(ranges, lineRange{Start: line, End: line})
+}
+
+// files возвращает файлы diff, существующие в новой версии, в порядке сортировки
+func (c changedLines) files() []string {
+	files := make([]string, 0, len(c))
+	for file := range c {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// filter оставляет проблемы, строки которых (от Line до EndLine) пересекаются с измененными строками
+func (c changedLines) filter(issues []report.Issue) []report.Issue {
+	var filtered []report.Issue
+	for _, issue := range issues {
+		end := issue.EndLine
+		if end < issue.Line {
+			end = issue.Line
+		}
+		for _, r := range c[filepath.Clean(issue.FilePath)] {
+			if issue.Line <= r.End && end >= r.Start {
+				filtered = append(filtered, issue)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// readFileList читает пути по одному в строке, пропуская пустые строки
+func readFileList(r io.Reader) ([]string, error) {
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, scanner.Err()
+}
+
+// filterListedFiles оставляет из списка существующие Go файлы вне исключенных директорий.
+// Отсутствующие файлы пропускаются: в выводе git diff --name-only есть удаленные файлы.
+func filterListedFiles(paths []string, excludeDirsList []string) []string {
+	var files []string
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".go") || inExcludedDir(path, excludeDirsList) {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			log.Debug().Str("path", path).Msg("Файл из списка не найден")
+			continue
+		}
+		files = append(files, path)
+	}
+	return files
+}
+
+// inExcludedDir проверяет, находится ли файл в директории с исключенным именем
+func inExcludedDir(path string, excludeDirsList []string) bool {
+	dirs := strings.Split(filepath.ToSlash(filepath.Dir(filepath.Clean(path))), "/")
+	for _, dir := range dirs {
+		for _, excludeDir := range excludeDirsList {
+			if excludeDir != "" && dir == excludeDir {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mergeFiles объединяет списки файлов без повторов, сохраняя порядок
+func mergeFiles(lists ...[]string) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, path := range list {
+			key := filepath.Clean(path)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// printUnusedSuppressions выводит директивы подавления, которые не скрыли ни одной проблемы
+func printUnusedSuppressions(w io.Writer, unused []analyzer.UnusedSuppression) {
+	fmt.Fprintf(w, "Неиспользуемых директив подавления: %d\n", len(unused))
+	for _, u := range unused {
+		fmt.Fprintf(w, "  %s:%d goaudit:ignore %s\n", u.FilePath, u.Line, u.RuleID)
+	}
+}
+
+// suppressionMechanisms задает порядок вывода механизмов подавления
+var suppressionMechanisms = []analyzer.SuppressionMechanism{
+	analyzer.SuppressionInline,
+	analyzer.SuppressionFile,
+	analyzer.SuppressionBaseline,
+	analyzer.SuppressionPath,
+}
+
+// directiveSuppressions возвращает проблемы, подавленные директивами goaudit:ignore и goaudit:disable в коде.
+// Базовая линия и переопределения для путей задаются в конфигурации и в строгом режиме не учитываются.
+func directiveSuppressions(suppressed []analyzer.SuppressedIssue) []analyzer.SuppressedIssue {
+	var result []analyzer.SuppressedIssue
+	for _, s := range suppressed {
+		if s.Mechanism == analyzer.SuppressionInline || s.Mechanism == analyzer.SuppressionFile {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// printStrictSuppressions выводит подавления, учтенные в строгом режиме, вместе с проблемами, которые они скрывали
+func printStrictSuppressions(w io.Writer, suppressed []analyzer.SuppressedIssue) {
+	fmt.Fprintf(w, "Режим -strict: учтено подавленных проблем: %d\n", len(suppressed))
+	for _, s := range suppressed {
+		fmt.Fprintf(w, "  [%s] %s %s:%d:%d %s\n",
+			s.Mechanism, s.Issue.RuleID, s.Issue.FilePath, s.Issue.Line, s.Issue.Column, s.Issue.Message)
+	}
+}
+
+// printSuppressions выводит статистику подавленных проблем по правилам и сами проблемы
+func printSuppressions(w io.Writer, stats analyzer.SuppressionStats, suppressed []analyzer.SuppressedIssue) {
+	fmt.Fprintf(w, "Подавлено проблем: %d\n", stats.Total())
+	for _, ruleID := range stats.RuleIDs() {
+		fmt.Fprintf(w, "  %s:", ruleID)
+		for _, mechanism := range suppressionMechanisms {
+			if count := stats[ruleID][mechanism]; count > 0 {
+				fmt.Fprintf(w, " %s=%d", mechanism, count)
+			}
 		}
-		log.Info().Str("file", *outputFile).Msg("Отчет записан в файл")
+		fmt.Fprintln(w)
 	}
 
-	// Выход с ненулевым статусом, если найдены проблемы
-	if len(results) > 0 {
-		os.Exit(2)
+	for _, s := range suppressed {
+		fmt.Fprintf(w, "  [%s] %s %s:%d:%d %s\n",
+			s.Mechanism, s.Issue.RuleID, s.Issue.FilePath, s.Issue.Line, s.Issue.Column, s.Issue.Message)
 	}
 }
@@ -0,0 +1,1583 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"go-audit/internal/analyzer"
+	"go-audit/pkg/config"
+	"go-audit/pkg/report"
+)
+
+// vulnerableCode содержит SQL-инъекцию для проверки CLI
+const vulnerableCode = `package main
+
+import "database/sql"
+
+func query(db *sql.DB, name string) {
+	db.Exec("DELETE FROM users WHERE name = '" + name + "'")
+}
+`
+
+// runCLI запускает команду и возвращает код завершения и содержимое stdout
+func runCLI(t *testing.T, stdin string, args ...string) (int, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	t.Logf("stderr: %s", stderr.String())
+	return code, stdout.String()
+}
+
+// parseJSONReport разбирает JSON-отчет из вывода команды
+func parseJSONReport(t *testing.T, output string) report.JSONReport {
+	t.Helper()
+
+	var jsonReport report.JSONReport
+	if err := json.Unmarshal([]byte(output), &jsonReport); err != nil {
+		t.Fatalf("Ошибка разбора JSON-отчета: %v\n%s", err, output)
+	}
+	return jsonReport
+}
+
+// TestRunCode проверяет анализ кода, переданного через -code
+func TestRunCode(t *testing.T) {
+	code, output := runCLI(t, "", "-format", "json", "-code", vulnerableCode)
+
+	if code != 2 {
+		t.Errorf("Код завершения = %d, ожидалось 2", code)
+	}
+
+	jsonReport := parseJSONReport(t, output)
+	found := false
+	for _, issue := range jsonReport.Issues {
+		if issue.RuleID == "SEC001" && issue.FilePath == stdinFileName {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Не найдена SQL-инъекция в %s: %+v", stdinFileName, jsonReport.Issues)
+	}
+}
+
+// TestRunMetadata проверяет сведения о запуске анализа в JSON-отчете
+func TestRunMetadata(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", "b.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(vulnerableCode), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	_, output := runCLI(t, "", "-format", "json", "-rules", "SEC001", dir)
+	jsonReport := parseJSONReport(t, output)
+
+	if jsonReport.ToolVersion != Version {
+		t.Errorf("toolVersion = %q, ожидалось %q", jsonReport.ToolVersion, Version)
+	}
+	if jsonReport.ScannedFiles != 2 {
+		t.Errorf("scannedFiles = %d, ожидалось 2", jsonReport.ScannedFiles)
+	}
+	if !reflect.DeepEqual(jsonReport.TargetPaths, []string{dir}) {
+		t.Errorf("targetPaths = %v, ожидалось [%s]", jsonReport.TargetPaths, dir)
+	}
+}
+
+// TestRunSuppressedInReports проверяет количество подавленных проблем в отчетах, записанных в файлы
+func TestRunSuppressedInReports(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	code := strings.Replace(vulnerableCode, "'\")\n", "'\") // goaudit:ignore SEC001\n", 1)
+	if err := os.WriteFile(file, []byte(code), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+	jsonPath, textPath := filepath.Join(dir, "report.json"), filepath.Join(dir, "report.txt")
+
+	runCLI(t, "", "-rules", "SEC001", "-format", "json,text", "-output", jsonPath+","+textPath, file)
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("JSON-отчет не создан: %v", err)
+	}
+	if jsonReport := parseJSONReport(t, string(data)); jsonReport.Suppressed != 1 {
+		t.Errorf("suppressed = %d, ожидалось 1", jsonReport.Suppressed)
+	}
+
+	text, err := os.ReadFile(textPath)
+	if err != nil {
+		t.Fatalf("Текстовый отчет не создан: %v", err)
+	}
+	if !strings.Contains(string(text), "Подавлено проблем: 1") {
+		t.Errorf("Текстовый отчет не содержит количество подавленных проблем:\n%s", text)
+	}
+}
+
+// TestRunOutputDirSuppressed проверяет, что отчет по файлу содержит количество подавленных проблем этого файла
+func TestRunOutputDirSuppressed(t *testing.T) {
+	dir := t.TempDir()
+	srcDir, outDir := filepath.Join(dir, "src"), filepath.Join(dir, "out")
+	if err := os.Mkdir(srcDir, 0755); err != nil {
+		t.Fatalf("Ошибка создания директории: %v", err)
+	}
+	ignored := strings.Replace(vulnerableCode, "'\")\n", "'\") // goaudit:ignore SEC001\n", 1) +
+		"\nvar password = \"SuperSecretPassword123\"\n"
+	files := map[string]string{"a.go": vulnerableCode, "b.go": ignored}
+	for name, code := range files {
+		code = strings.Replace(code, "func ", "func "+strings.TrimSuffix(name, ".go"), 1)
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(code), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	runCLI(t, "", "-rules", "SEC001,SEC002", "-format", "json", "-output-dir", outDir, srcDir)
+
+	for name, want := range map[string]int{"a.go": 0, "b.go": 1} {
+		data, err := os.ReadFile(filepath.Join(outDir, reportRelPath(filepath.Join(srcDir, name))+".json"))
+		if err != nil {
+			t.Fatalf("Отчет для %s не записан: %v", name, err)
+		}
+		jsonReport := parseJSONReport(t, string(data))
+		if jsonReport.Suppressed != want {
+			t.Errorf("suppressed для %s = %d, ожидалось %d", name, jsonReport.Suppressed, want)
+		}
+		if jsonReport.ScannedFiles != 1 {
+			t.Errorf("scannedFiles для %s = %d, ожидалось 1", name, jsonReport.ScannedFiles)
+		}
+	}
+}
+
+// TestRunJUnitCleanFiles проверяет, что JUnit-отчет содержит набор тестов для каждого проанализированного файла
+func TestRunJUnitCleanFiles(t *testing.T) {
+	dir := t.TempDir()
+	vulnerable, clean := filepath.Join(dir, "main.go"), filepath.Join(dir, "clean.go")
+	if err := os.WriteFile(vulnerable, []byte(vulnerableCode), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+	if err := os.WriteFile(clean, []byte("package main\n\nfunc helper() {}\n"), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	_, output := runCLI(t, "", "-rules", "SEC001", "-format", "junit", dir)
+
+	var suites report.JUnitTestSuites
+	if err := xml.Unmarshal([]byte(output), &suites); err != nil {
+		t.Fatalf("Ошибка разбора JUnit-отчета: %v\n%s", err, output)
+	}
+	tests := make(map[string]int)
+	for _, suite := range suites.TestSuites {
+		tests[suite.Name] = suite.Tests
+	}
+	if n, ok := tests[clean]; !ok || n != 0 {
+		t.Errorf("Для файла без проблем ожидался пустой набор тестов, получено %+v", suites.TestSuites)
+	}
+	if tests[vulnerable] == 0 {
+		t.Errorf("Для файла с проблемой нет тестов: %+v", suites.TestSuites)
+	}
+	if len(suites.TestSuites) != 2 {
+		t.Errorf("len(TestSuites) = %d, ожидалось 2", len(suites.TestSuites))
+	}
+}
+
+// TestRunFixes проверяет запись предлагаемых исправлений в файл -fixes
+func TestRunFixes(t *testing.T) {
+	dir := t.TempDir()
+	code := "package main\n\nimport \"io/ioutil\"\n\nvar read = ioutil.ReadAll\n"
+	file := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(file, []byte(code), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+	fixesPath := filepath.Join(dir, "fixes.json")
+
+	runCLI(t, "", "-rules", "SEC024", "-fixes", fixesPath, file)
+
+	data, err := os.ReadFile(fixesPath)
+	if err != nil {
+		t.Fatalf("Файл исправлений не создан: %v", err)
+	}
+	var fixesReport report.FixesReport
+	if err := json.Unmarshal(data, &fixesReport); err != nil {
+		t.Fatalf("Ошибка разбора файла исправлений: %v\n%s", err, data)
+	}
+	if len(fixesReport.Fixes) != 1 {
+		t.Fatalf("Ожидалось 1 исправление, получено %d: %s", len(fixesReport.Fixes), data)
+	}
+	fix := fixesReport.Fixes[0]
+	if got := code[fix.StartOffset:fix.EndOffset]; got != "ioutil.ReadAll" || fix.Replacement != "io.ReadAll" {
+		t.Errorf("Исправление заменяет %q на %q, ожидалась замена ioutil.ReadAll на io.ReadAll", got, fix.Replacement)
+	}
+}
+
+// TestRunApplyFixes проверяет применение однозначных исправлений к файлам
+func TestRunApplyFixes(t *testing.T) {
+	dir := t.TempDir()
+	safe := filepath.Join(dir, "safe.go")
+	unsafe := filepath.Join(dir, "unsafe.go")
+	unsafeCode := "package main\n\nimport \"io/ioutil\"\n\nvar load = ioutil.ReadFile\n"
+	files := map[string]string{
+		safe: `package main
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+func read(r io.Reader) ([]byte, error) {
+	io.Copy(ioutil.Discard, r)
+	return ioutil.ReadAll(r)
+}
+`,
+		unsafe: unsafeCode,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	run([]string{"-rules", "SEC024", "-apply-fixes", safe, unsafe}, strings.NewReader(""), &stdout, &stderr)
+
+	fixed, err := os.ReadFile(safe)
+	if err != nil {
+		t.Fatalf("Ошибка чтения файла: %v", err)
+	}
+	if !strings.Contains(string(fixed), "io.Copy(io.Discard, r)") || !strings.Contains(string(fixed), "return io.ReadAll(r)") {
+		t.Errorf("Исправления не применены:\n%s", fixed)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), safe, fixed, 0); err != nil {
+		t.Errorf("Исправленный файл не разбирается: %v", err)
+	}
+	// Импорт io/ioutil без оставшихся вызовов удаляется
+	if strings.Contains(string(fixed), "io/ioutil") {
+		t.Errorf("Неиспользуемый импорт io/ioutil не удален:\n%s", fixed)
+	}
+
+	// Исправление, требующее нового импорта, не применяется
+	if data, _ := os.ReadFile(unsafe); string(data) != unsafeCode {
+		t.Errorf("Файл с неоднозначным исправлением изменен:\n%s", data)
+	}
+
+	if !strings.Contains(stderr.String(), "Применено исправлений: 2") {
+		t.Errorf("Ожидалась сводка о 2 исправлениях: %s", stderr.String())
+	}
+}
+
+// TestRunApplyFixesCompiles проверяет, что после исправлений файл компилируется: ReadDir не заменяется,
+// а импорт io/ioutil остается, пока в файле есть его вызовы
+func TestRunApplyFixesCompiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	code := `package main
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+func sizes(dir string) (int64, error) {
+	if _, err := ioutil.ReadFile(dir + "/index"); err != nil {
+		return 0, err
+	}
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, fi := range infos {
+		total += fi.Size()
+	}
+	return total, nil
+}
+
+var _ = os.Getenv
+`
+	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	run([]string{"-rules", "SEC024", "-apply-fixes", path}, strings.NewReader(""), &stdout, &stderr)
+
+	fixed, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Ошибка чтения файла: %v", err)
+	}
+	if !strings.Contains(string(fixed), "os.ReadFile(") || !strings.Contains(string(fixed), "ioutil.ReadDir(dir)") {
+		t.Errorf("Ожидалась замена только ReadFile:\n%s", fixed)
+	}
+	if !strings.Contains(string(fixed), `"io/ioutil"`) {
+		t.Errorf("Импорт io/ioutil удален, хотя ReadDir остался:\n%s", fixed)
+	}
+	if errs, err := packageTypeErrors(path, fixed); err != nil || len(errs) != 0 {
+		t.Errorf("Исправленный файл не компилируется: %v %v", errs, err)
+	}
+}
+
+// TestApplyFileFixesRollback проверяет, что файл не изменяется, если после исправлений он не разбирается
+// или не проходит проверку типов
+func TestApplyFileFixesRollback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	code := "package main\n\nvar enabled bool = true\n"
+	if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	start := strings.Index(code, "true")
+	issues := []report.Issue{{
+		RuleID:       "SEC003",
+		FilePath:     path,
+		Line:         3,
+		SuggestedFix: &report.SuggestedFix{StartOffset: start, EndOffset: start + len("true"), Replacement: "}{", SafeToApply: true},
+	}}
+
+	if applied := applyFixes(issues); len(applied) != 0 {
+		t.Errorf("Ожидалось 0 примененных исправлений, получено %d", len(applied))
+	}
+	if data, _ := os.ReadFile(path); string(data) != code {
+		t.Errorf("Файл должен остаться без изменений:\n%s", data)
+	}
+
+	// Исправление, которое разбирается, но нарушает проверку типов, тоже откатывается
+	issues[0].SuggestedFix.Replacement = "1"
+	if applied := applyFixes(issues); len(applied) != 0 {
+		t.Errorf("Ожидалось 0 примененных исправлений с ошибкой типов, получено %d", len(applied))
+	}
+	if data, _ := os.ReadFile(path); string(data) != code {
+		t.Errorf("Файл с ошибкой типов должен остаться без изменений:\n%s", data)
+	}
+}
+
+// TestRunCodeFileStdin проверяет чтение кода из stdin через -code-file -
+func TestRunCodeFileStdin(t *testing.T) {
+	code, output := runCLI(t, vulnerableCode, "-format", "json", "-code-file", "-")
+
+	if code != 2 {
+		t.Errorf("Код завершения = %d, ожидалось 2", code)
+	}
+
+	if jsonReport := parseJSONReport(t, output); jsonReport.TotalIssues == 0 {
+		t.Error("Не найдены проблемы в коде из stdin")
+	}
+}
+
+// TestRunCodeIgnoresExclude проверяет, что исключения не применяются к синтетическому файлу
+func TestRunCodeIgnoresExclude(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"exclude": ["*.go", "stdin.go"]}`), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+
+	code, output := runCLI(t, "", "-config", configPath, "-format", "json", "-code", vulnerableCode)
+
+	if code != 2 {
+		t.Errorf("Код завершения = %d, ожидалось 2", code)
+	}
+
+	if jsonReport := parseJSONReport(t, output); jsonReport.TotalIssues == 0 {
+		t.Error("Исключения применены к коду, переданному через -code")
+	}
+}
+
+// TestRunCheck проверяет, что режим -check не выводит ничего, кроме кода завершения
+func TestRunCheck(t *testing.T) {
+	testCases := []struct {
+		name     string
+		code     string
+		expected int
+	}{
+		{
+			name:     "dirty code",
+			code:     vulnerableCode,
+			expected: 2,
+		},
+		{
+			name:     "clean code",
+			code:     "package main\n\nfunc main() {}\n",
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "main.go")
+			if err := os.WriteFile(path, []byte(tc.code), 0644); err != nil {
+				t.Fatalf("Ошибка записи файла: %v", err)
+			}
+
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"-check", path}, strings.NewReader(""), &stdout, &stderr)
+
+			if code != tc.expected {
+				t.Errorf("Код завершения = %d, ожидалось %d", code, tc.expected)
+			}
+			if stdout.Len() != 0 || stderr.Len() != 0 {
+				t.Errorf("Ожидался пустой вывод, stdout: %q, stderr: %q", stdout.String(), stderr.String())
+			}
+		})
+	}
+}
+
+// TestMatchedCWEs проверяет сопоставление найденных проблем со списком -fail-on-cwe
+func TestMatchedCWEs(t *testing.T) {
+	issues := []report.Issue{
+		{RuleID: "SEC001", CWE: "CWE-89"},
+		{RuleID: "SEC001", CWE: "CWE-89"},
+		{RuleID: "SEC003", CWE: "CWE-319"},
+		{RuleID: "MOCK001"},
+	}
+
+	testCases := []struct {
+		name     string
+		cwes     []string
+		expected []string
+	}{
+		{
+			name:     "matching cwe",
+			cwes:     []string{"CWE-89"},
+			expected: []string{"CWE-89"},
+		},
+		{
+			name:     "short and lowercase forms",
+			cwes:     []string{"89", " cwe-319"},
+			expected: []string{"CWE-89", "CWE-319"},
+		},
+		{
+			name:     "cwe without findings",
+			cwes:     []string{"CWE-327"},
+			expected: nil,
+		},
+		{
+			name:     "empty list",
+			cwes:     nil,
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched := matchedCWEs(issues, tc.cwes)
+			if strings.Join(matched, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("matchedCWEs(%v) = %v, ожидалось %v", tc.cwes, matched, tc.expected)
+			}
+		})
+	}
+}
+
+// TestRunFailOnCWE проверяет сообщение о срабатывании -fail-on-cwe
+func TestRunFailOnCWE(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cwes      string
+		triggered bool
+	}{
+		{
+			name:      "matching cwe",
+			cwes:      "CWE-89",
+			triggered: true,
+		},
+		{
+			name:      "cwe without findings",
+			cwes:      "CWE-327",
+			triggered: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"-fail-on-cwe", tc.cwes, "-code", vulnerableCode}, strings.NewReader(""), &stdout, &stderr)
+
+			if code != 2 {
+				t.Errorf("Код завершения = %d, ожидалось 2", code)
+			}
+			if triggered := strings.Contains(stderr.String(), "запрещенными CWE"); triggered != tc.triggered {
+				t.Errorf("Срабатывание -fail-on-cwe = %v, ожидалось %v\n%s", triggered, tc.triggered, stderr.String())
+			}
+		})
+	}
+}
+
+// TestRunRuleCoverage проверяет вывод срабатываний правил, включая правила без срабатываний
+func TestRunRuleCoverage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-rule-coverage", "-format", "json", "-code", vulnerableCode}, strings.NewReader(""), &stdout, &stderr)
+	if code != 2 {
+		t.Fatalf("Код завершения = %d, ожидалось 2", code)
+	}
+
+	var coverage []analyzer.RuleCoverage
+	// За покрытием следует итоговая строка, поэтому разбирается только первое значение
+	if err := json.NewDecoder(&stderr).Decode(&coverage); err != nil {
+		t.Fatalf("Ошибка разбора покрытия правил: %v\n%s", err, stderr.String())
+	}
+
+	hits := make(map[string]int)
+	for _, rule := range coverage {
+		hits[rule.RuleID] = rule.Hits
+	}
+
+	if hits["SEC001"] == 0 {
+		t.Errorf("Ожидались срабатывания SEC001, получено %v", coverage)
+	}
+
+	count, ok := hits["SEC010"]
+	if !ok {
+		t.Errorf("Правило SEC010 без срабатываний отсутствует в покрытии: %v", coverage)
+	} else if count != 0 {
+		t.Errorf("SEC010: получено %d срабатываний, ожидалось 0", count)
+	}
+}
+
+// TestRunSARIF проверяет, что SARIF-отчет содержит метаданные всех правил
+func TestRunSARIF(t *testing.T) {
+	code, output := runCLI(t, "", "-format", "sarif", "-code", vulnerableCode)
+	if code != 2 {
+		t.Errorf("Код завершения = %d, ожидалось 2", code)
+	}
+
+	var sarif report.SARIFLog
+	if err := json.Unmarshal([]byte(output), &sarif); err != nil {
+		t.Fatalf("Ошибка разбора SARIF-отчета: %v\n%s", err, output)
+	}
+
+	if len(sarif.Runs) != 1 || len(sarif.Runs[0].Results) == 0 {
+		t.Fatalf("Ожидался один запуск с результатами: %+v", sarif.Runs)
+	}
+
+	expected := len(analyzer.New(nil).Rules())
+	if got := len(sarif.Runs[0].Tool.Driver.Rules); got != expected {
+		t.Errorf("Правил в tool.driver.rules: %d, ожидалось %d", got, expected)
+	}
+}
+
+// TestRunMinSeverity проверяет фильтрацию отчета и кода завершения по -min-severity
+func TestRunMinSeverity(t *testing.T) {
+	// Проблема уровня INFO: незавершенная доработка безопасности
+	infoCode := `package main
+
+func handle(isAdmin bool) {
+	// TODO: verify auth properly
+	if isAdmin {
+		return
+	}
+}
+`
+
+	testCases := []struct {
+		name        string
+		code        string
+		minSeverity string
+		exitCode    int
+	}{
+		{name: "info finding at info threshold", code: infoCode, minSeverity: "INFO", exitCode: 2},
+		{name: "info finding below low threshold", code: infoCode, minSeverity: "LOW", exitCode: 0},
+		{name: "critical finding at critical threshold", code: vulnerableCode, minSeverity: "critical", exitCode: 2},
+		{name: "invalid threshold", code: vulnerableCode, minSeverity: "SEVERE", exitCode: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, output := runCLI(t, "", "-format", "json", "-min-severity", tc.minSeverity, "-code", tc.code)
+			if code != tc.exitCode {
+				t.Fatalf("Код завершения = %d, ожидалось %d", code, tc.exitCode)
+			}
+			if code == 1 {
+				return
+			}
+
+			threshold, _ := report.ParseSeverity(tc.minSeverity)
+			jsonReport := parseJSONReport(t, output)
+			for _, issue := range jsonReport.Issues {
+				if !issue.Severity.AtLeast(threshold) {
+					t.Errorf("Проблема %s с серьезностью %s ниже порога %s", issue.RuleID, issue.Severity, threshold)
+				}
+			}
+		})
+	}
+}
+
+// TestRunDemotedRules проверяет, что правило, пониженное переопределением или списком demotedRules,
+// скрывается порогом -min-severity и остается в отчете при пороге INFO
+func TestRunDemotedRules(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      string
+		args        []string
+		minSeverity string
+		expected    report.Severity
+	}{
+		{
+			name:        "override below threshold",
+			config:      `{"severityOverrides": {"SEC001": "LOW"}}`,
+			minSeverity: "MEDIUM",
+		},
+		{
+			name:        "override at threshold",
+			config:      `{"severityOverrides": {"SEC001": "LOW"}}`,
+			minSeverity: "LOW",
+			expected:    report.SeverityLow,
+		},
+		{
+			name:        "demoted rule takes precedence over override",
+			config:      `{"severityOverrides": {"SEC001": "HIGH"}, "demotedRules": ["SEC001"]}`,
+			minSeverity: "LOW",
+		},
+		{
+			name:        "demoted rule from flag",
+			config:      `{}`,
+			args:        []string{"-demote-rules", "SEC001"},
+			minSeverity: "LOW",
+		},
+		{
+			name:        "demoted rule at info threshold",
+			config:      `{"demotedRules": ["SEC001"]}`,
+			minSeverity: "INFO",
+			expected:    report.SeverityInfo,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "main.go")
+			if err := os.WriteFile(file, []byte(vulnerableCode), 0644); err != nil {
+				t.Fatalf("Ошибка записи файла: %v", err)
+			}
+			configPath := filepath.Join(dir, "config.json")
+			if err := os.WriteFile(configPath, []byte(tc.config), 0644); err != nil {
+				t.Fatalf("Ошибка записи конфигурации: %v", err)
+			}
+
+			args := append([]string{"-format", "json", "-config", configPath, "-min-severity", tc.minSeverity}, tc.args...)
+			code, output := runCLI(t, "", append(args, file)...)
+			if code == 1 {
+				t.Fatalf("Код завершения = %d, ожидался отчет", code)
+			}
+
+			var found []report.Severity
+			for _, issue := range parseJSONReport(t, output).Issues {
+				if issue.RuleID == "SEC001" {
+					found = append(found, issue.Severity)
+				}
+			}
+			if tc.expected == "" {
+				if len(found) != 0 {
+					t.Errorf("SEC001 ниже порога %s не должно быть в отчете: %v", tc.minSeverity, found)
+				}
+				return
+			}
+			if len(found) != 1 || found[0] != tc.expected {
+				t.Errorf("SEC001 в отчете: %v, ожидалась одна проблема с серьезностью %s", found, tc.expected)
+			}
+		})
+	}
+
+	if code, _ := runCLI(t, "", "-demote-rules", "SEC999", "-code", vulnerableCode); code != 1 {
+		t.Errorf("Код завершения для неизвестного правила = %d, ожидалось 1", code)
+	}
+}
+
+// TestRunBaseline проверяет, что при наличии базовой линии сообщается только о новых проблемах
+func TestRunBaseline(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "main.go")
+	baselinePath := filepath.Join(dir, "baseline.json")
+
+	if err := os.WriteFile(filePath, []byte(vulnerableCode), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	if code, _ := runCLI(t, "", "-baseline", baselinePath, "-update-baseline", filePath); code != 0 {
+		t.Fatalf("Код завершения обновления базовой линии = %d, ожидалось 0", code)
+	}
+
+	code, output := runCLI(t, "", "-format", "json", "-baseline", baselinePath, filePath)
+	if code != 0 {
+		t.Fatalf("Без новых проблем код завершения = %d, ожидалось 0", code)
+	}
+	if issues := parseJSONReport(t, output).Issues; len(issues) != 0 {
+		t.Fatalf("Ожидалось 0 новых проблем, получено %d", len(issues))
+	}
+
+	// Новая функция выше существующей сдвигает известные проблемы на несколько строк
+	newFunc := "func remove(db *sql.DB, id string) {\n\tdb.Exec(\"DELETE FROM orders WHERE id = '\" + id + \"'\")\n}\n\n"
+	updated := strings.Replace(vulnerableCode, "func query", newFunc+"func query", 1)
+	if err := os.WriteFile(filePath, []byte(updated), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	code, output = runCLI(t, "", "-format", "json", "-baseline", baselinePath, filePath)
+	if code != 2 {
+		t.Fatalf("С новой проблемой код завершения = %d, ожидалось 2", code)
+	}
+
+	issues := parseJSONReport(t, output).Issues
+	if len(issues) == 0 {
+		t.Fatal("Новая проблема не найдена")
+	}
+	newLine := strings.Count(updated[:strings.Index(updated, "func remove")], "\n") + 2
+	for _, issue := range issues {
+		if issue.Line != newLine {
+			t.Errorf("Сообщено о проблеме %s в строке %d, ожидалась только строка %d", issue.RuleID, issue.Line, newLine)
+		}
+	}
+}
+
+// TestShouldFail проверяет решение о коде завершения для наборов проблем разной серьезности
+func TestShouldFail(t *testing.T) {
+	mixed := []report.Issue{
+		{RuleID: "SEC009", Severity: report.SeverityInfo},
+		{RuleID: "SEC004", Severity: report.SeverityMedium},
+		{RuleID: "SEC003", Severity: report.SeverityHigh},
+	}
+	infoOnly := []report.Issue{{RuleID: "SEC009", Severity: report.SeverityInfo}}
+
+	testCases := []struct {
+		name      string
+		issues    []report.Issue
+		threshold report.Severity
+		expected  bool
+	}{
+		{name: "no issues", issues: nil, threshold: "", expected: false},
+		{name: "any issue by default", issues: infoOnly, threshold: "", expected: true},
+		{name: "info below high threshold", issues: infoOnly, threshold: report.SeverityHigh, expected: false},
+		{name: "mixed at high threshold", issues: mixed, threshold: report.SeverityHigh, expected: true},
+		{name: "mixed at medium threshold", issues: mixed, threshold: report.SeverityMedium, expected: true},
+		{name: "mixed below critical threshold", issues: mixed, threshold: report.SeverityCritical, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := shouldFail(tc.issues, tc.threshold); got != tc.expected {
+				t.Errorf("shouldFail() = %v, ожидалось %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestRunFailOn проверяет, что -fail-on влияет только на код завершения, но не на отчет
+func TestRunFailOn(t *testing.T) {
+	infoCode := "package main\n\nfunc handle(isAdmin bool) {\n\t// TODO: verify auth properly\n\tif isAdmin {\n\t\treturn\n\t}\n}\n"
+
+	code, output := runCLI(t, "", "-format", "json", "-fail-on", "high", "-code", infoCode)
+	if code != 0 {
+		t.Fatalf("Код завершения = %d, ожидалось 0", code)
+	}
+	if issues := parseJSONReport(t, output).Issues; len(issues) == 0 {
+		t.Error("Проблема INFO должна попасть в отчет")
+	}
+
+	if code, _ := runCLI(t, "", "-check", "-fail-on", "critical", "-code", vulnerableCode); code != 2 {
+		t.Errorf("Код завершения для CRITICAL = %d, ожидалось 2", code)
+	}
+
+	if code, _ := runCLI(t, "", "-fail-on", "SEVERE", "-code", infoCode); code != 1 {
+		t.Errorf("Код завершения для некорректного порога = %d, ожидалось 1", code)
+	}
+
+	// Без -fail-on к ошибке приводит любая проблема, в том числе информационная
+	if code, _ := runCLI(t, "", "-check", "-code", infoCode); code != 2 {
+		t.Errorf("Код завершения для INFO без -fail-on = %d, ожидалось 2", code)
+	}
+}
+
+// TestFilterListedFiles проверяет отбор файлов из списка -files-from
+func TestFilterListedFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "README.md", "vendor/lib/lib.go", "pkg/util.go"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Ошибка создания директории: %v", err)
+		}
+		if err := os.WriteFile(path, []byte("package main\n"), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	list := strings.Join([]string{
+		filepath.Join(dir, "main.go"),
+		"",
+		filepath.Join(dir, "README.md"),
+		filepath.Join(dir, "vendor", "lib", "lib.go"),
+		"  " + filepath.Join(dir, "pkg", "util.go") + "  ",
+		filepath.Join(dir, "deleted.go"),
+	}, "\n")
+
+	paths, err := readFileList(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("Ошибка чтения списка: %v", err)
+	}
+	if len(paths) != 5 {
+		t.Fatalf("Прочитано %d путей, ожидалось 5", len(paths))
+	}
+
+	files := filterListedFiles(paths, []string{"vendor"})
+	expected := []string{filepath.Join(dir, "main.go"), filepath.Join(dir, "pkg", "util.go")}
+	if fmt.Sprint(files) != fmt.Sprint(expected) {
+		t.Errorf("Файлы = %v, ожидалось %v", files, expected)
+	}
+
+	merged := mergeFiles([]string{filepath.Join(dir, "main.go")}, files)
+	if fmt.Sprint(merged) != fmt.Sprint(expected) {
+		t.Errorf("Объединенный список = %v, ожидалось %v", merged, expected)
+	}
+}
+
+// TestRunFilesFrom проверяет анализ файлов, список которых передан через stdin
+func TestRunFilesFrom(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(filePath, []byte(vulnerableCode), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	code, output := runCLI(t, filePath+"\n", "-format", "json", "-files-from", "-")
+	if code != 2 {
+		t.Fatalf("Код завершения = %d, ожидалось 2", code)
+	}
+	if issues := parseJSONReport(t, output).Issues; len(issues) == 0 {
+		t.Fatal("Проблемы в файле из списка не найдены")
+	}
+
+	if code, _ := runCLI(t, "", "-files-from", "-", "-code-file", "-"); code != 1 {
+		t.Errorf("При двойном чтении stdin код завершения = %d, ожидалось 1", code)
+	}
+}
+
+// TestRunPackagePattern проверяет раскрытие шаблонов пакетов ./... и путей импорта модуля
+func TestRunPackagePattern(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                 "module example.com/app\n\ngo 1.22\n",
+		"main.go":                vulnerableCode,
+		"internal/db/db.go":      strings.Replace(vulnerableCode, "package main", "package db", 1),
+		"internal/skip/skip.go":  strings.Replace(vulnerableCode, "package main", "package skip", 1),
+		"testdata/fixture.go":    vulnerableCode,
+		"_old/old.go":            vulnerableCode,
+		"tools/go.mod":           "module example.com/app/tools\n",
+		"tools/tool.go":          vulnerableCode,
+		"internal/db/schema.sql": "CREATE TABLE users (name TEXT);\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Ошибка создания директории: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+	t.Chdir(dir)
+
+	// analyzedFiles возвращает файлы с проблемами SEC001 в отчете
+	analyzedFiles := func(args ...string) []string {
+		t.Helper()
+		_, output := runCLI(t, "", append([]string{"-format", "json", "-rules", "SEC001"}, args...)...)
+		var paths []string
+		for _, issue := range parseJSONReport(t, output).Issues {
+			paths = append(paths, filepath.ToSlash(issue.FilePath))
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "current module",
+			args:     []string{"./..."},
+			expected: []string{"internal/db/db.go", "internal/skip/skip.go", "main.go"},
+		},
+		{
+			name:     "subdirectory with exclude",
+			args:     []string{"-exclude", "skip", "./internal/..."},
+			expected: []string{"internal/db/db.go"},
+		},
+		{
+			name:     "import path pattern",
+			args:     []string{"example.com/app/internal/..."},
+			expected: []string{filepath.ToSlash(filepath.Join(dir, "internal/db/db.go")), filepath.ToSlash(filepath.Join(dir, "internal/skip/skip.go"))},
+		},
+		{
+			name:     "import path of package",
+			args:     []string{"example.com/app/internal/db"},
+			expected: []string{filepath.ToSlash(filepath.Join(dir, "internal/db/db.go"))},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if paths := analyzedFiles(tc.args...); !reflect.DeepEqual(paths, tc.expected) {
+				t.Errorf("Проблемы найдены в файлах %v, ожидалось %v", paths, tc.expected)
+			}
+		})
+	}
+
+	// Пакет вне модуля, как и отсутствующий файл, пропускается с ошибкой в журнале
+	if paths := analyzedFiles("example.com/other/..."); len(paths) != 0 {
+		t.Errorf("Для пакета вне модуля найдены проблемы в файлах %v", paths)
+	}
+}
+
+// TestParseDiff проверяет извлечение добавленных строк из unified diff
+func TestParseDiff(t *testing.T) {
+	diff := `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -3,3 +3,5 @@ import "database/sql"
+ func query(db *sql.DB, name string) {
+-	db.Exec("DELETE FROM users")
++	db.Exec("DELETE FROM users WHERE name = ?", name)
++	db.Exec("VACUUM")
+ }
++
+@@ -20,1 +22,2 @@ func other() {
+ 	a := 1
++	b := 2
+\ No newline at end of file
+diff --git a/old.go b/old.go
+deleted file mode 100644
+--- a/old.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package main
+-
+--- pkg/util.go	2024-01-01 10:00:00
++++ pkg/util.go	2024-01-02 10:00:00
+@@ -1 +1 @@
+-package util
++package utils
+`
+
+	lines, err := parseDiff(strings.NewReader(diff))
+	if err != nil {
+		t.Fatalf("Ошибка разбора diff: %v", err)
+	}
+
+	expected := changedLines{
+		"main.go":                         {{Start: 4, End: 5}, {Start: 7, End: 7}, {Start: 23, End: 23}},
+		filepath.FromSlash("pkg/util.go"): {{Start: 1, End: 1}},
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("parseDiff() = %v, ожидалось %v", lines, expected)
+	}
+
+	issues := []report.Issue{
+		{RuleID: "SEC001", FilePath: "main.go", Line: 5},
+		{RuleID: "SEC002", FilePath: "main.go", Line: 10},
+		{RuleID: "SEC003", FilePath: "main.go", Line: 21, EndLine: 23},
+		{RuleID: "SEC004", FilePath: "other.go", Line: 1},
+	}
+	var ids []string
+	for _, issue := range lines.filter(issues) {
+		ids = append(ids, issue.RuleID)
+	}
+	if !reflect.DeepEqual(ids, []string{"SEC001", "SEC003"}) {
+		t.Errorf("После фильтрации остались проблемы %v, ожидалось [SEC001 SEC003]", ids)
+	}
+
+	// Строки фрагмента, похожие на заголовки файлов, остаются содержимым фрагмента
+	lines, err = parseDiff(strings.NewReader("--- a/notes.go\n+++ b/notes.go\n@@ -1,2 +1,2 @@\n package notes\n--- removed\n+++ added\n"))
+	if err != nil {
+		t.Fatalf("Ошибка разбора diff: %v", err)
+	}
+	if expected := (changedLines{"notes.go": {{Start: 2, End: 2}}}); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("parseDiff() = %v, ожидалось %v", lines, expected)
+	}
+
+	if _, err := parseDiff(strings.NewReader("+++ b/main.go\n@@ broken @@\n")); err == nil {
+		t.Error("Ожидалась ошибка для некорректного заголовка фрагмента")
+	}
+}
+
+// TestRunDiff проверяет, что в режиме -diff в отчет попадают только проблемы в измененных строках
+func TestRunDiff(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	// Строка 6 содержала SQL-инъекцию до изменения, функция remove добавлена в diff
+	content := vulnerableCode + `
+func remove(db *sql.DB, id string) {
+	db.Exec("DELETE FROM sessions WHERE id = '" + id + "'")
+}
+`
+	if err := os.WriteFile("main.go", []byte(content), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+	diff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -6,2 +6,6 @@ func query(db *sql.DB, name string) {
+ 	db.Exec("DELETE FROM users WHERE name = '" + name + "'")
+ }
++
++func remove(db *sql.DB, id string) {
++	db.Exec("DELETE FROM sessions WHERE id = '" + id + "'")
++}
+`
+
+	code, output := runCLI(t, diff, "-format", "json", "-rules", "SEC001", "-diff", "-")
+	if code != 2 {
+		t.Fatalf("Код завершения = %d, ожидалось 2", code)
+	}
+	issues := parseJSONReport(t, output).Issues
+	if len(issues) != 1 || issues[0].Line != 10 {
+		t.Fatalf("Ожидалась 1 проблема в строке 10 из diff, получено %+v", issues)
+	}
+
+	// Без -diff находится и проблема, существовавшая до изменения
+	_, output = runCLI(t, "", "-format", "json", "-rules", "SEC001", "main.go")
+	if issues := parseJSONReport(t, output).Issues; len(issues) != 2 {
+		t.Errorf("Без -diff ожидалось 2 проблемы, получено %d", len(issues))
+	}
+
+	if code, _ := runCLI(t, diff, "-diff", "-", "-files-from", "-"); code != 1 {
+		t.Errorf("При двойном чтении stdin код завершения = %d, ожидалось 1", code)
+	}
+}
+
+// TestRunRules проверяет выбор правил флагами -rules и -skip-rules
+func TestRunRules(t *testing.T) {
+	code, output := runCLI(t, "", "-format", "json", "-rules", "SEC003", "-code", vulnerableCode)
+	if code != 0 {
+		t.Fatalf("Код завершения = %d, ожидалось 0", code)
+	}
+	if issues := parseJSONReport(t, output).Issues; len(issues) != 0 {
+		t.Errorf("С -rules SEC003 ожидалось 0 проблем, получено %d", len(issues))
+	}
+
+	code, output = runCLI(t, "", "-format", "json", "-skip-rules", "SEC001", "-code", vulnerableCode)
+	for _, issue := range parseJSONReport(t, output).Issues {
+		if issue.RuleID == "SEC001" {
+			t.Errorf("Пропущенное правило SEC001 сообщило о проблеме (код завершения %d)", code)
+		}
+	}
+
+	for _, args := range [][]string{{"-rules", "SEC001,SEC999"}, {"-skip-rules", "NOPE"}} {
+		if code, _ := runCLI(t, "", append(args, "-code", vulnerableCode)...); code != 1 {
+			t.Errorf("%v: код завершения = %d, ожидалось 1", args, code)
+		}
+	}
+}
+
+// TestRunInit проверяет создание файла конфигурации по умолчанию
+func TestRunInit(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	if code, output := runCLI(t, "", "-init"); code != 0 {
+		t.Fatalf("Код завершения -init = %d, ожидалось 0: %s", code, output)
+	}
+
+	cfg, err := config.Load(config.ConfigFileName)
+	if err != nil {
+		t.Fatalf("Ошибка загрузки созданной конфигурации: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, config.DefaultConfig()) {
+		t.Errorf("Созданная конфигурация не соответствует конфигурации по умолчанию: %+v", cfg)
+	}
+
+	// Число процессоров машины, на которой создан файл, не записывается: иначе файл, созданный
+	// на машине с одним процессором, ограничил бы анализ на всех остальных одним потоком
+	data, err := os.ReadFile(config.ConfigFileName)
+	if err != nil {
+		t.Fatalf("Ошибка чтения конфигурации: %v", err)
+	}
+	if strings.Contains(string(data), "concurrency") {
+		t.Errorf("Созданная конфигурация не должна задавать concurrency:\n%s", data)
+	}
+
+	// Существующий файл не перезаписывается без -force
+	if err := os.WriteFile(config.ConfigFileName, []byte(`{"disabledRules": ["SEC002"]}`), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+	if code, _ := runCLI(t, "", "-init"); code != 1 {
+		t.Errorf("Код завершения -init для существующего файла = %d, ожидалось 1", code)
+	}
+	if cfg, err := config.Load(config.ConfigFileName); err != nil || len(cfg.DisabledRules) != 1 {
+		t.Errorf("Существующая конфигурация не должна перезаписываться: %+v, %v", cfg, err)
+	}
+
+	if code, _ := runCLI(t, "", "-init", "-force"); code != 0 {
+		t.Fatalf("Код завершения -init -force = %d, ожидалось 0", code)
+	}
+	if cfg, err := config.Load(config.ConfigFileName); err != nil || !reflect.DeepEqual(cfg, config.DefaultConfig()) {
+		t.Errorf("Конфигурация должна быть перезаписана значениями по умолчанию: %+v, %v", cfg, err)
+	}
+
+	// -config задает путь и формат создаваемого файла
+	if code, _ := runCLI(t, "", "-init", "-config", "gosecheck.yaml"); code != 0 {
+		t.Fatalf("Код завершения -init -config = %d, ожидалось 0", code)
+	}
+	if cfg, err := config.Load("gosecheck.yaml"); err != nil || !reflect.DeepEqual(cfg, config.DefaultConfig()) {
+		t.Errorf("YAML-конфигурация не соответствует конфигурации по умолчанию: %+v, %v", cfg, err)
+	}
+	if data, err := os.ReadFile("gosecheck.yaml"); err != nil || strings.Contains(string(data), "concurrency") {
+		t.Errorf("YAML-конфигурация не должна задавать concurrency: %s, %v", data, err)
+	}
+}
+
+// TestRunUnknownConfigRules проверяет ошибку запуска с неизвестным правилом в конфигурации
+func TestRunUnknownConfigRules(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(file, []byte(vulnerableCode), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+	configPath := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"enabledRules": ["SEC001", "SEC999"]}`), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-config", configPath, file}, strings.NewReader(""), &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("Код завершения = %d, ожидалось 1", code)
+	}
+	if !strings.Contains(stderr.String(), "SEC999") {
+		t.Errorf("Ошибка должна указывать неизвестное правило SEC999: %s", stderr.String())
+	}
+}
+
+// TestRunListRules проверяет вывод списка правил в текстовом и JSON-формате
+func TestRunListRules(t *testing.T) {
+	code, output := runCLI(t, "", "-list-rules")
+	if code != 0 {
+		t.Fatalf("Код завершения = %d, ожидалось 0", code)
+	}
+	for _, rule := range analyzer.DefaultRules() {
+		if !strings.Contains(output, rule.ID()) || !strings.Contains(output, rule.Description()) {
+			t.Errorf("Список правил не содержит %s", rule.ID())
+		}
+	}
+
+	code, output = runCLI(t, "", "-list-rules", "-format", "json")
+	if code != 0 {
+		t.Fatalf("Код завершения = %d, ожидалось 0", code)
+	}
+	var infos []ruleInfo
+	if err := json.Unmarshal([]byte(output), &infos); err != nil {
+		t.Fatalf("Ошибка разбора JSON: %v\n%s", err, output)
+	}
+	ids := make(map[string]bool)
+	for _, info := range infos {
+		ids[info.ID] = true
+		if info.Severity == "" || info.Description == "" {
+			t.Errorf("Неполное описание правила: %+v", info)
+		}
+	}
+	for _, rule := range analyzer.DefaultRules() {
+		if !ids[rule.ID()] {
+			t.Errorf("JSON-список не содержит %s", rule.ID())
+		}
+	}
+
+	if code, _ := runCLI(t, "", "-list-rules", "-format", "sarif"); code != 1 {
+		t.Errorf("Для неподдерживаемого формата код завершения = %d, ожидалось 1", code)
+	}
+}
+
+// TestRunSummary проверяет, что итоговая строка выводится в stderr и не попадает в отчет
+func TestRunSummary(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-format", "json", "-progress", "1", "-code", vulnerableCode}, strings.NewReader(""), &stdout, &stderr)
+	if code != 2 {
+		t.Fatalf("Код завершения = %d, ожидалось 2", code)
+	}
+
+	jsonReport := parseJSONReport(t, stdout.String())
+	summary := fmt.Sprintf("Проверено файлов: 1, найдено проблем: %d (CRITICAL: ", len(jsonReport.Issues))
+	if !strings.Contains(stderr.String(), summary) {
+		t.Errorf("Итоговая строка %q не найдена в stderr:\n%s", summary, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "Прогресс анализа") {
+		t.Errorf("Прогресс анализа не выведен в stderr:\n%s", stderr.String())
+	}
+}
+
+// TestRunStrict проверяет, что -strict возвращает в отчет проблемы, подавленные директивами
+func TestRunStrict(t *testing.T) {
+	suppressedCode := strings.Replace(vulnerableCode, "'\")\n", "'\") // goaudit:ignore SEC001\n", 1)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-format", "json", "-rules", "SEC001", "-code", suppressedCode}, strings.NewReader(""), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Код завершения без -strict = %d, ожидалось 0", code)
+	}
+	if issues := parseJSONReport(t, stdout.String()).Issues; len(issues) != 0 {
+		t.Errorf("Без -strict подавленная проблема не должна попадать в отчет: %+v", issues)
+	}
+	if !strings.Contains(stderr.String(), "подавлено: 1") {
+		t.Errorf("Итоговая строка должна содержать количество подавленных проблем:\n%s", stderr.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	code = run([]string{"-format", "json", "-rules", "SEC001", "-strict", "-code", suppressedCode}, strings.NewReader(""), &stdout, &stderr)
+	if code != 2 {
+		t.Fatalf("Код завершения с -strict = %d, ожидалось 2", code)
+	}
+	jsonReport := parseJSONReport(t, stdout.String())
+	if issues := jsonReport.Issues; len(issues) != 1 || issues[0].RuleID != "SEC001" {
+		t.Errorf("С -strict ожидалась подавленная проблема SEC001, получено: %+v", issues)
+	}
+	if !strings.Contains(stderr.String(), "[inline] SEC001 stdin.go:") {
+		t.Errorf("Подавление не выведено в stderr:\n%s", stderr.String())
+	}
+	// Возвращенная в отчет проблема не учитывается повторно как подавленная
+	if jsonReport.Suppressed != 0 || !strings.Contains(stderr.String(), "подавлено: 0") {
+		t.Errorf("С -strict suppressed = %d, ожидалось 0:\n%s", jsonReport.Suppressed, stderr.String())
+	}
+}
+
+// TestRunStrictFileDirective проверяет, что -strict учитывает проблемы правила, отключенного для всего файла
+func TestRunStrictFileDirective(t *testing.T) {
+	disabledCode := "//goaudit:disable SEC001\n" + vulnerableCode
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-format", "text", "-rules", "SEC001", "-code", disabledCode}, strings.NewReader(""), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Код завершения без -strict = %d, ожидалось 0", code)
+	}
+	if !strings.Contains(stdout.String(), "Подавлено проблем: 1") {
+		t.Errorf("Отчет должен учитывать проблему, подавленную директивой файла:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	code = run([]string{"-format", "json", "-rules", "SEC001", "-strict", "-code", disabledCode}, strings.NewReader(""), &stdout, &stderr)
+	if code != 2 {
+		t.Fatalf("Код завершения с -strict = %d, ожидалось 2", code)
+	}
+	issues := parseJSONReport(t, stdout.String()).Issues
+	if len(issues) != 1 || issues[0].RuleID != "SEC001" {
+		t.Errorf("С -strict ожидалась подавленная проблема SEC001, получено: %+v", issues)
+	}
+	if !strings.Contains(stderr.String(), "[file] SEC001 stdin.go:") {
+		t.Errorf("Подавление не выведено в stderr:\n%s", stderr.String())
+	}
+}
+
+// TestRunCache проверяет, что повторный запуск с -cache-dir использует кэш и выдает тот же отчет
+func TestRunCache(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	filePath := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(filePath, []byte(vulnerableCode), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	_, first := runCLI(t, "", "-format", "json", "-cache-dir", cacheDir, filePath)
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("Кэш не записан в %s: %v", cacheDir, err)
+	}
+
+	_, second := runCLI(t, "", "-format", "json", "-cache-dir", cacheDir, filePath)
+	firstIssues, secondIssues := parseJSONReport(t, first).Issues, parseJSONReport(t, second).Issues
+	if len(firstIssues) == 0 || !reflect.DeepEqual(firstIssues, secondIssues) {
+		t.Errorf("Отчет из кэша не совпадает с исходным:\n%+v\n%+v", secondIssues, firstIssues)
+	}
+}
+
+// TestRunParseErrors проверяет предупреждение о файлах, пропущенных из-за ошибок разбора
+func TestRunParseErrors(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "broken.go")
+	if err := os.WriteFile(invalid, []byte("package main\n\nfunc {"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{invalid}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+		t.Errorf("Код завершения = %d, ожидалось 0", code)
+	}
+	if !strings.Contains(stderr.String(), "пропущено файлов из-за ошибок: 1") || !strings.Contains(stderr.String(), invalid) {
+		t.Errorf("Не выведено предупреждение о пропущенном файле:\n%s", stderr.String())
+	}
+}
+
+// TestParseReportTargets проверяет сопоставление форматов и выходных файлов
+func TestParseReportTargets(t *testing.T) {
+	testCases := []struct {
+		name     string
+		formats  string
+		outputs  string
+		expected []reportTarget
+		wantErr  bool
+	}{
+		{
+			name:     "single format to stdout",
+			formats:  "json",
+			expected: []reportTarget{{format: "json"}},
+		},
+		{
+			name:     "single format to file",
+			formats:  "sarif",
+			outputs:  "results.sarif",
+			expected: []reportTarget{{format: "sarif", path: "results.sarif"}},
+		},
+		{
+			name:     "text to stdout and sarif to file",
+			formats:  "text,sarif",
+			outputs:  "-,results.sarif",
+			expected: []reportTarget{{format: "text"}, {format: "sarif", path: "results.sarif"}},
+		},
+		{
+			name:    "missing outputs",
+			formats: "text,sarif",
+			wantErr: true,
+		},
+		{
+			name:    "count mismatch",
+			formats: "text,sarif",
+			outputs: "-,a.sarif,b.json",
+			wantErr: true,
+		},
+		{
+			name:    "two formats to stdout",
+			formats: "text,json",
+			outputs: "-,-",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			targets, err := parseReportTargets(tc.formats, tc.outputs)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseReportTargets(%q, %q) ошибка = %v, ожидалась ошибка: %v", tc.formats, tc.outputs, err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(targets, tc.expected) {
+				t.Errorf("parseReportTargets(%q, %q) = %+v, ожидалось %+v", tc.formats, tc.outputs, targets, tc.expected)
+			}
+		})
+	}
+}
+
+// TestParsePathRuleOverrides проверяет разбор значения -exclude-rule-in-path
+func TestParsePathRuleOverrides(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected []config.PathRuleOverride
+		wantErr  bool
+	}{
+		{
+			name:     "single mapping",
+			value:    "cmd/**=SEC006",
+			expected: []config.PathRuleOverride{{PathGlob: "cmd/**", DisableRules: []string{"SEC006"}}},
+		},
+		{
+			name:  "several mappings",
+			value: "cmd/** = SEC006, SEC004; *_mock.go=SEC002;",
+			expected: []config.PathRuleOverride{
+				{PathGlob: "cmd/**", DisableRules: []string{"SEC006", "SEC004"}},
+				{PathGlob: "*_mock.go", DisableRules: []string{"SEC002"}},
+			},
+		},
+		{
+			name:    "missing rules",
+			value:   "cmd/**=",
+			wantErr: true,
+		},
+		{
+			name:    "missing separator",
+			value:   "cmd/**",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			overrides, err := parsePathRuleOverrides(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parsePathRuleOverrides(%q) ошибка = %v, ожидалась ошибка: %v", tc.value, err, tc.wantErr)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(overrides, tc.expected) {
+				t.Errorf("parsePathRuleOverrides(%q) = %+v, ожидалось %+v", tc.value, overrides, tc.expected)
+			}
+		})
+	}
+}
+
+// TestWriteReports проверяет запись отчетов в нескольких форматах в stdout и файл
+func TestWriteReports(t *testing.T) {
+	sarifPath := filepath.Join(t.TempDir(), "results.sarif")
+	issues := []report.Issue{{RuleID: "SEC001", Severity: report.SeverityCritical, Message: "SQL-инъекция", FilePath: "main.go", Line: 3}}
+
+	var stdout bytes.Buffer
+	targets := []reportTarget{{format: "text"}, {format: "sarif", path: sarifPath}}
+	if err := writeReports(&stdout, issues, targets, analyzer.New(nil).Rules(), nil, nil); err != nil {
+		t.Fatalf("Ошибка записи отчетов: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "SEC001") || strings.Contains(stdout.String(), "\"$schema\"") {
+		t.Errorf("Ожидался только текстовый отчет в stdout:\n%s", stdout.String())
+	}
+
+	data, err := os.ReadFile(sarifPath)
+	if err != nil {
+		t.Fatalf("Ошибка чтения SARIF-отчета: %v", err)
+	}
+	var sarif map[string]interface{}
+	if err := json.Unmarshal(data, &sarif); err != nil || sarif["runs"] == nil {
+		t.Errorf("Некорректный SARIF-отчет: %v\n%s", err, data)
+	}
+}
+
+// TestWriteFileReports проверяет запись отдельных отчетов по файлам в дерево директорий
+func TestWriteFileReports(t *testing.T) {
+	dir := t.TempDir()
+	issues := []report.Issue{
+		{RuleID: "SEC001", Severity: report.SeverityCritical, Message: "SQL-инъекция", FilePath: "cmd/app/main.go", Line: 3},
+		{RuleID: "SEC002", Severity: report.SeverityHigh, Message: "Секрет", FilePath: "internal/db/query.go", Line: 7},
+		{RuleID: "SEC004", Severity: report.SeverityMedium, Message: "Ошибка не проверена", FilePath: "cmd/app/main.go", Line: 9},
+	}
+
+	targets := []reportTarget{{format: "json"}, {format: "text"}}
+	if err := writeFileReports(dir, issues, targets, analyzer.New(nil).Rules(), nil, nil, nil); err != nil {
+		t.Fatalf("Ошибка записи отчетов: %v", err)
+	}
+
+	expected := map[string][]string{
+		"cmd/app/main.go":      {"SEC001", "SEC004"},
+		"internal/db/query.go": {"SEC002"},
+	}
+	for file, ruleIDs := range expected {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)+".json"))
+		if err != nil {
+			t.Fatalf("Отчет для %s не записан: %v", file, err)
+		}
+		fileIssues := parseJSONReport(t, string(data)).Issues
+		if len(fileIssues) != len(ruleIDs) {
+			t.Fatalf("Отчет для %s содержит %d проблем, ожидалось %d", file, len(fileIssues), len(ruleIDs))
+		}
+		for i, issue := range fileIssues {
+			if issue.RuleID != ruleIDs[i] || issue.FilePath != file {
+				t.Errorf("Отчет для %s содержит проблему %s в %s", file, issue.RuleID, issue.FilePath)
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file)+".txt")); err != nil {
+			t.Errorf("Текстовый отчет для %s не записан: %v", file, err)
+		}
+	}
+
+	// Других файлов в директории отчетов нет
+	var written []string
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			written = append(written, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if len(written) != 4 {
+		t.Errorf("Ожидалось 4 файла отчетов, записаны: %v", written)
+	}
+}
+
+// TestReportRelPath проверяет, что пути отчетов по файлам не выходят за пределы директории отчетов
+func TestReportRelPath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Ошибка получения текущей директории: %v", err)
+	}
+
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"main.go", "main.go"},
+		{"./cmd/app/main.go", "cmd/app/main.go"},
+		{"../shared/util.go", "shared/util.go"},
+		{filepath.Join(wd, "pkg", "db.go"), "pkg/db.go"},
+	}
+
+	for _, tc := range testCases {
+		if got := filepath.ToSlash(reportRelPath(tc.path)); got != tc.expected {
+			t.Errorf("reportRelPath(%q) = %q, ожидалось %q", tc.path, got, tc.expected)
+		}
+	}
+}
+
+// TestRunMultipleFormats проверяет отказ при несовпадении количества форматов и выходных файлов
+func TestRunMultipleFormats(t *testing.T) {
+	code, output := runCLI(t, "", "-format", "text,sarif", "-code", vulnerableCode)
+	if code != 1 || output != "" {
+		t.Errorf("Код завершения = %d, вывод %q, ожидалось 1 без отчета", code, output)
+	}
+
+	code, output = runCLI(t, "", "-output", "report.txt", "-output-dir", t.TempDir(), "-code", vulnerableCode)
+	if code != 1 || output != "" {
+		t.Errorf("С -output и -output-dir код завершения = %d, вывод %q, ожидалось 1 без отчета", code, output)
+	}
+}
+
+// TestDecideExitCode проверяет выбор кода завершения по найденным проблемам
+func TestDecideExitCode(t *testing.T) {
+	issues := []report.Issue{{RuleID: "SEC002", Severity: report.SeverityMedium, CWE: "CWE-798"}}
+
+	testCases := []struct {
+		name     string
+		issues   []report.Issue
+		opts     exitOptions
+		expected int
+	}{
+		{name: "no issues", expected: exitOK},
+		{name: "issues found", issues: issues, expected: exitIssues},
+		{name: "issues below fail-on", issues: issues, opts: exitOptions{failOn: report.SeverityHigh}, expected: exitOK},
+		{name: "forbidden cwe below fail-on", issues: issues, opts: exitOptions{failOn: report.SeverityHigh, failedCWEs: []string{"CWE-798"}}, expected: exitIssues},
+		{name: "no-fail with issues", issues: issues, opts: exitOptions{noFail: true}, expected: exitOK},
+		{name: "no-fail with forbidden cwe", issues: issues, opts: exitOptions{noFail: true, failedCWEs: []string{"CWE-798"}}, expected: exitOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code := decideExitCode(tc.issues, tc.opts); code != tc.expected {
+				t.Errorf("decideExitCode() = %d, ожидалось %d", code, tc.expected)
+			}
+		})
+	}
+}
+
+// TestRunExitCodes проверяет коды завершения команды для ошибок выполнения, найденных проблем и -no-fail
+func TestRunExitCodes(t *testing.T) {
+	missingConfig := filepath.Join(t.TempDir(), "missing.json")
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{name: "clean code", args: []string{"-code", "package main\n\nfunc main() {}\n"}, expected: exitOK},
+		{name: "issues found", args: []string{"-code", vulnerableCode}, expected: exitIssues},
+		{name: "no-fail", args: []string{"-no-fail", "-code", vulnerableCode}, expected: exitOK},
+		{name: "no-fail in check mode", args: []string{"-no-fail", "-check", "-code", vulnerableCode}, expected: exitOK},
+		{name: "missing config", args: []string{"-config", missingConfig, "-code", vulnerableCode}, expected: exitError},
+		{name: "unknown flag", args: []string{"-unknown-flag"}, expected: exitError},
+		{name: "invalid source", args: []string{"-no-fail", "-code", "package main\n\nfunc {"}, expected: exitError},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if code, _ := runCLI(t, "", tc.args...); code != tc.expected {
+				t.Errorf("Код завершения = %d, ожидалось %d", code, tc.expected)
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@ module go-audit
 
 go 1.24
 
-require github.com/rs/zerolog v1.32.0
+require (
+	github.com/rs/zerolog v1.32.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
 	golang.org/x/sys v0.20.0 // indirect
-)
\ No newline at end of file
+)
@@ -1,11 +1,15 @@
 package analyzer
 
 import (
-	_ "go/ast"
+	"context"
+	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 
 	"github.com/rs/zerolog/log"
@@ -18,91 +22,287 @@ import (
 type Analyzer struct {
 	config *config.Config
 	rules  []rules.Rule
+
+	// Проблемы, скрытые механизмами подавления
+	suppressed   []SuppressedIssue
+	suppressedMu sync.Mutex
+
+	// Файлы, пропущенные из-за ошибок чтения или разбора
+	analysisErrors []AnalysisError
+	errorsMu       sync.Mutex
+
+	// Директивы подавления, не скрывшие ни одной проблемы
+	unusedSuppressions []UnusedSuppression
+
+	// Пользовательские обработчики найденных проблем
+	processors []IssueProcessor
+
+	// Импортер пакетов для проверки типов
+	importer *cachingImporter
+
+	// Базовая линия известных проблем
+	baseline *report.Baseline
+
+	// Счетчики обработанных файлов
+	progress progress
+
+	// Кэш результатов анализа неизмененных файлов
+	cache *issueCache
+}
+
+// IssueProcessor обрабатывает собранные проблемы перед формированием отчета.
+// Может фильтровать, дополнять или изменять проблемы и возвращает итоговый список.
+type IssueProcessor func([]report.Issue) []report.Issue
+
+// Option настраивает Analyzer при создании
+type Option func(*Analyzer)
+
+// WithIssueProcessor добавляет обработчик найденных проблем.
+// Обработчики вызываются в порядке регистрации после всех встроенных шагов,
+// в том числе после переопределений серьезности из конфигурации.
+func WithIssueProcessor(processor IssueProcessor) Option {
+	return func(a *Analyzer) {
+		a.processors = append(a.processors, processor)
+	}
 }
 
-// New создает новый Analyzer с предоставленной конфигурацией
-func New(cfg *config.Config) *Analyzer {
-	return &Analyzer{
-		config: cfg,
-		rules: []rules.Rule{
-			rules.NewSQLInjectionRule(),
-			rules.NewHardcodedSecretsRule(),
-			rules.NewInsecureHTTPRule(),
-			rules.NewMissingErrorCheckRule(),
-			rules.NewInsecureCryptoRule(),
-			rules.NewInsecureUserInputRule(),
-		},
+// WithRulesAddedSince оставляет только правила, появившиеся в указанной версии или позже.
+// Используется для оценки ложных срабатываний новых правил на большом корпусе кода.
+func WithRulesAddedSince(version string) Option {
+	return func(a *Analyzer) {
+		a.rules = rules.AddedSince(a.rules, version)
 	}
 }
 
-// AnalyzeFiles выполняет анализ безопасности указанных Go-файлов
+// WithBaseline скрывает проблемы, известные базовой линии.
+// Скрытые проблемы учитываются в статистике подавления с механизмом baseline.
+func WithBaseline(baseline *report.Baseline) Option {
+	return func(a *Analyzer) {
+		a.baseline = baseline
+	}
+}
+
+// New создает новый Analyzer с предоставленной конфигурацией.
+// Анализатор использует встроенные правила и правила, зарегистрированные через RegisterRule.
+func New(cfg *config.Config, opts ...Option) *Analyzer {
+	a := &Analyzer{
+		config:   cfg,
+		importer: newCachingImporter(),
+		rules:    allRules(),
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// FileResult содержит результат анализа одного файла
+type FileResult struct {
+	// Путь к файлу
+	Path string
+	// Найденные проблемы
+	Issues []report.Issue
+	// Ошибка чтения или разбора файла
+	Err error
+	// Файл пропущен без анализа
+	Skipped bool
+	// Причина пропуска файла
+	SkipReason string
+}
+
+// Rules возвращает правила анализатора
+func (a *Analyzer) Rules() []rules.Rule {
+	result := make([]rules.Rule, len(a.rules))
+	copy(result, a.rules)
+	return result
+}
+
+// AnalyzeFiles выполняет анализ безопасности указанных Go-файлов.
+// Файлы с ошибками чтения или разбора пропускаются и доступны через AnalysisErrors.
 func (a *Analyzer) AnalyzeFiles(filePaths []string) ([]report.Issue, error) {
+	return a.AnalyzeFilesContext(context.Background(), filePaths)
+}
+
+// AnalyzeFilesContext выполняет анализ указанных Go-файлов с возможностью отмены.
+// После отмены контекста новые файлы не запускаются на анализ, а метод дожидается уже начатых
+// и возвращает проблемы обработанных файлов вместе с ctx.Err().
+func (a *Analyzer) AnalyzeFilesContext(ctx context.Context, filePaths []string) ([]report.Issue, error) {
+	a.resetSuppressions()
+
+	var allIssues []report.Issue
+
+	for _, result := range a.analyzeFiles(ctx, filePaths) {
+		if result.Err != nil {
+			log.Error().Err(result.Err).Str("file", result.Path).Msg("Ошибка анализа файла")
+			continue
+		}
+		allIssues = append(allIssues, result.Issues...)
+	}
+
+	return a.processIssues(allIssues), ctx.Err()
+}
+
+// AnalyzeFilesDetailed выполняет анализ указанных файлов и возвращает результат по каждому файлу
+// в порядке входного списка. Пользовательские обработчики применяются к проблемам каждого файла отдельно.
+func (a *Analyzer) AnalyzeFilesDetailed(filePaths []string) []FileResult {
+	a.resetSuppressions()
+	results := a.analyzeFiles(context.Background(), filePaths)
+	for i := range results {
+		if len(results[i].Issues) > 0 {
+			results[i].Issues = a.processIssues(results[i].Issues)
+		}
+	}
+	return results
+}
+
+// analyzeFiles параллельно анализирует файлы и возвращает результаты в порядке входного списка.
+// Файлы, не запущенные на анализ до отмены контекста, отмечаются как пропущенные.
+func (a *Analyzer) analyzeFiles(ctx context.Context, filePaths []string) []FileResult {
 	var (
-		allIssues []report.Issue
-		mu        sync.Mutex
+		results   = make([]FileResult, len(filePaths))
 		wg        sync.WaitGroup
-		semaphore = make(chan struct{}, 10) // Ограничиваем количество одновременных горутин
+		semaphore = make(chan struct{}, a.concurrency()) // Ограничиваем количество одновременных горутин
 	)
+	a.progress.addTotal(len(filePaths))
 
-	for _, filePath := range filePaths {
-		wg.Add(1)
-		semaphore <- struct{}{} // Получаем семафор
+	for i, filePath := range filePaths {
+		// Получаем семафор или прекращаем запуск новых файлов после отмены
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// Отмена могла произойти одновременно с получением семафора
+		if ctx.Err() != nil {
+			for j := i; j < len(filePaths); j++ {
+				results[j] = FileResult{Path: filePaths[j], Skipped: true, SkipReason: "анализ отменен"}
+			}
+			break
+		}
 
-		go func(path string) {
+		wg.Add(1)
+		go func(i int, path string) {
 			defer wg.Done()
 			defer func() { <-semaphore }() // Освобождаем семафор
 
-			issues, err := a.analyzeFile(path)
-			if err != nil {
-				log.Error().Err(err).Str("file", path).Msg("Ошибка анализа файла")
-				return
+			results[i] = a.analyzeFile(path)
+			if results[i].Err != nil {
+				a.recordError(path, results[i].Err)
 			}
-
-			if len(issues) > 0 {
-				mu.Lock()
-				allIssues = append(allIssues, issues...)
-				mu.Unlock()
-
-				log.Debug().Str("file", path).Int("issues", len(issues)).Msg("Найдены проблемы в файле")
+			a.progress.fileDone()
+			if len(results[i].Issues) > 0 {
+				log.Debug().Str("file", path).Int("issues", len(results[i].Issues)).Msg("Найдены проблемы в файле")
 			}
-		}(filePath)
+		}(i, filePath)
 	}
 
 	wg.Wait()
-	return allIssues, nil
+	return results
+}
+
+// AnalyzeSource выполняет анализ исходного кода, переданного напрямую.
+// Исключения из конфигурации к имени файла не применяются.
+func (a *Analyzer) AnalyzeSource(filename string, src []byte) ([]report.Issue, error) {
+	a.resetSuppressions()
+	a.progress.addTotal(1)
+	issues, err := a.analyzeSource(filename, src)
+	a.progress.fileDone()
+	if err != nil {
+		return nil, err
+	}
+	return a.processIssues(issues), nil
+}
+
+// processIssues отбрасывает проблемы из базовой линии и применяет пользовательские обработчики
+func (a *Analyzer) processIssues(issues []report.Issue) []report.Issue {
+	if a.baseline != nil {
+		var matched []report.Issue
+		issues, matched = a.baseline.Filter(issues)
+		for _, issue := range matched {
+			a.recordSuppressed(issue, SuppressionBaseline)
+		}
+	}
+
+	for _, processor := range a.processors {
+		issues = processor(issues)
+	}
+	return issues
 }
 
 // analyzeFile анализирует один Go-файл
-func (a *Analyzer) analyzeFile(filePath string) ([]report.Issue, error) {
+func (a *Analyzer) analyzeFile(filePath string) FileResult {
+	result := FileResult{Path: filePath}
+
 	// Проверяем, должен ли файл быть исключен
 	if a.config != nil && a.config.ShouldExclude(filePath) {
 		log.Debug().Str("file", filePath).Msg("Файл исключен из анализа")
-		return nil, nil
+		result.Skipped = true
+		result.SkipReason = "файл исключен конфигурацией"
+		return result
 	}
 
-	fset := token.NewFileSet()
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, err
+		result.Err = err
+		return result
+	}
+
+	if a.skipGenerated() && isGenerated(content) {
+		log.Debug().Str("file", filePath).Msg("Сгенерированный файл пропущен")
+		result.Skipped = true
+		result.SkipReason = "сгенерированный файл"
+		return result
 	}
 
+	var cacheKey string
+	if a.cache != nil {
+		cacheKey = a.cacheKey(filePath, content)
+		if issues, ok := a.loadCached(cacheKey); ok {
+			log.Debug().Str("file", filePath).Msg("Результат анализа взят из кэша")
+			result.Issues = issues
+			return result
+		}
+	}
+
+	result.Issues, result.Err = a.analyzeSource(filePath, content)
+	if a.cache != nil && result.Err == nil {
+		a.storeCached(cacheKey, filePath, result.Issues)
+	}
+	return result
+}
+
+// analyzeSource разбирает исходный код и применяет к нему включенные правила
+func (a *Analyzer) analyzeSource(filePath string, content []byte) ([]report.Issue, error) {
+	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
 	if err != nil {
 		return nil, err
 	}
 
+	files := []*ast.File{file}
+	return a.checkFile(fset, file, filePath, content, files, a.typeCheck(fset, files)), nil
+}
+
+// checkFile применяет включенные правила к разобранному файлу.
+// packageFiles содержит все файлы пакета, разобранные вместе с текущим.
+func (a *Analyzer) checkFile(fset *token.FileSet, file *ast.File, filePath string, content []byte, packageFiles []*ast.File, info *types.Info) []report.Issue {
 	var issues []report.Issue
 	fileDir := filepath.Dir(filePath)
 
 	ctx := &rules.Context{
-		FileSet:     fset,
-		File:        file,
-		Config:      a.config,
-		FilePath:    filePath,
-		FileDir:     fileDir,
-		FileContent: content,
-		Package:     file.Name.Name,
+		FileSet:      fset,
+		File:         file,
+		Config:       a.config,
+		FilePath:     filePath,
+		FileDir:      fileDir,
+		FileContent:  content,
+		Package:      file.Name.Name,
+		PackageFiles: packageFiles,
+		TypesInfo:    info,
+		Parents:      rules.BuildParentMap(file),
 	}
+	directives := collectFileDirectives(fset, file)
 
 	for _, rule := range a.rules {
 		if !a.isRuleEnabled(rule.ID()) {
@@ -110,12 +310,85 @@ func (a *Analyzer) analyzeFile(filePath string) ([]report.Issue, error) {
 			continue
 		}
 
+		if a.config != nil {
+			ctx.Settings = a.config.GetRuleSettings(rule.ID())
+		}
+		if !rules.IsApplicable(rule, ctx) {
+			log.Debug().Str("rule", rule.ID()).Str("file", filePath).Msg("Правило неприменимо к файлу")
+			continue
+		}
+
 		log.Debug().Str("rule", rule.ID()).Str("file", filePath).Msg("Запуск проверки правилом")
 		ruleIssues := rule.Check(ctx)
+
+		// Проблемы правил, отключенных для пути файла, учитываются как подавленные
+		if a.config != nil && a.config.IsRuleDisabledForPath(rule.ID(), filePath) {
+			log.Debug().Str("rule", rule.ID()).Str("file", filePath).Msg("Правило отключено для пути")
+			for _, issue := range ruleIssues {
+				a.recordSuppressed(issue, SuppressionPath)
+			}
+			continue
+		}
+
+		// Применяем переопределения серьезности из конфигурации
+		if a.config != nil {
+			for i := range ruleIssues {
+				ruleIssues[i].Severity = a.config.ResolveSeverity(ruleIssues[i].RuleID, ruleIssues[i].Severity)
+			}
+		}
 		issues = append(issues, ruleIssues...)
 	}
 
-	return issues, nil
+	issues = dedupeIssues(issues)
+
+	// Отпечатки для сравнения с базовой линией
+	lines := strings.Split(string(content), "\n")
+	for i := range issues {
+		var lineContext string
+		if line := issues[i].Line; line > 0 && line <= len(lines) {
+			lineContext = lines[line-1]
+		}
+		issues[i].Fingerprint = report.Fingerprint(issues[i], lineContext)
+	}
+
+	issues = a.applyFileDirectives(directives, issues)
+	return a.applyInlineSuppressions(fset, file, filePath, content, issues)
+}
+
+// dedupeIssues удаляет одинаковые проблемы, о которых правило сообщило несколько раз
+// для одной позиции, сохраняя порядок первых вхождений
+func dedupeIssues(issues []report.Issue) []report.Issue {
+	type issueKey struct {
+		ruleID   string
+		filePath string
+		line     int
+		column   int
+		message  string
+	}
+
+	seen := make(map[issueKey]bool, len(issues))
+	var result []report.Issue
+	for _, issue := range issues {
+		key := issueKey{issue.RuleID, issue.FilePath, issue.Line, issue.Column, issue.Message}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, issue)
+	}
+	return result
+}
+
+// concurrency возвращает максимальное количество одновременных горутин анализа.
+// Без конфигурации используется число процессоров, некорректное значение заменяется на 1.
+func (a *Analyzer) concurrency() int {
+	if a.config == nil {
+		return runtime.NumCPU()
+	}
+	if a.config.Concurrency <= 0 {
+		return 1
+	}
+	return a.config.Concurrency
 }
 
 // isRuleEnabled проверяет, включено ли правило в конфигурации
@@ -1,17 +1,164 @@
 package analyzer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/rs/zerolog"
 	"go-audit/internal/rules"
 	"go-audit/pkg/config"
 	"go-audit/pkg/report"
 )
 
+// benchCorpusSizeEnv задает переменную окружения с количеством файлов в корпусе для бенчмарков
+const benchCorpusSizeEnv = "GOAUDIT_BENCH_FILES"
+
+// defaultBenchCorpusSize количество файлов в корпусе по умолчанию
+const defaultBenchCorpusSize = 100
+
+// benchCorpus содержит пути к файлам корпуса, созданного в TestMain
+var benchCorpus []string
+
+// benchTemplates набор шаблонов, покрывающих шаблоны уязвимостей всех правил
+var benchTemplates = []string{
+	`package bench%d
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func query%d(db *sql.DB, username string) {
+	query := "SELECT * FROM users WHERE username = '" + username + "'"
+	db.Query(query)
+	db.Exec(fmt.Sprintf("DELETE FROM users WHERE name = '%%s'", username))
+}
+`,
+	`package bench%d
+
+const apiKey%d = "1234567890abcdef1234567890abcdef"
+
+func secrets() {
+	password := "SuperSecretPassword123"
+	_ = password
+}
+`,
+	`package bench%d
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+func server%d() {
+	cfg := &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS10}
+	_ = cfg
+	http.ListenAndServe(":8080", nil)
+	http.Get("http://example.com/api")
+}
+`,
+	`package bench%d
+
+import "os"
+
+func files%d(name string) {
+	f, _ := os.Open(name)
+	f.Close()
+	out, err := os.Create(name + ".bak")
+	if err != nil {
+		return
+	}
+	out.Write([]byte("data"))
+}
+`,
+	`package bench%d
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/des"
+)
+
+func hashes%d(data []byte) {
+	md5.New()
+	sha1.New()
+	des.NewCipher(data)
+}
+`,
+	`package bench%d
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+)
+
+func handler%d(w http.ResponseWriter, r *http.Request) {
+	command := r.URL.Query().Get("cmd")
+	exec.Command("sh", "-c", command).Run()
+	filename := r.FormValue("file")
+	os.Open(filename)
+}
+`,
+	`package bench%d
+
+import "github.com/golang-jwt/jwt/v5"
+
+type Claims%d struct {
+	UserID string
+}
+
+func parse(token string, keyFunc jwt.Keyfunc) {
+	jwt.Parse(token, keyFunc, jwt.WithoutClaimsValidation())
+	jwt.ParseWithClaims(token, &Claims%[2]d{}, keyFunc)
+}
+`,
+}
+
+// TestMain создает детерминированный корпус файлов для бенчмарков
+func TestMain(m *testing.M) {
+	size := defaultBenchCorpusSize
+	if value := os.Getenv(benchCorpusSizeEnv); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			fmt.Fprintf(os.Stderr, "Некорректное значение %s: %q\n", benchCorpusSizeEnv, value)
+			os.Exit(1)
+		}
+		size = n
+	}
+
+	corpusDir, err := os.MkdirTemp("", "goaudit-bench")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ошибка создания директории корпуса: %v\n", err)
+		os.Exit(1)
+	}
+
+	for i := 0; i < size; i++ {
+		content := fmt.Sprintf(benchTemplates[i%len(benchTemplates)], i, i)
+		path := filepath.Join(corpusDir, fmt.Sprintf("bench%d.go", i))
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Ошибка записи файла корпуса: %v\n", err)
+			os.RemoveAll(corpusDir)
+			os.Exit(1)
+		}
+		benchCorpus = append(benchCorpus, path)
+	}
+
+	code := m.Run()
+	os.RemoveAll(corpusDir)
+	os.Exit(code)
+}
+
 // TestNew проверяет создание нового анализатора
 func TestNew(t *testing.T) {
 	cfg := config.DefaultConfig()
@@ -38,6 +185,29 @@ func TestNew(t *testing.T) {
 		"*rules.MissingErrorCheckRule",
 		"*rules.InsecureCryptoRule",
 		"*rules.InsecureUserInputRule",
+		"*rules.InsecureJWTRule",
+		"*rules.InsecureDeserializationRule",
+		"*rules.SecurityMarkerRule",
+		"*rules.WeakSessionIDRule",
+		"*rules.MultipartLimitRule",
+		"*rules.InsecureTempFileRule",
+		"*rules.InsecureRandomRule",
+		"*rules.InsecureDBConnectionRule",
+		"*rules.InsecureFilePermsRule",
+		"*rules.HardcodedKeyMaterialRule",
+		"*rules.HighComplexityRule",
+		"*rules.AbruptTerminationRule",
+		"*rules.OpenRedirectRule",
+		"*rules.SSRFRule",
+		"*rules.MissingSecurityHeadersRule",
+		"*rules.UnusedCancelRule",
+		"*rules.UnboundedBodyReadRule",
+		"*rules.DeprecatedIoutilRule",
+		"*rules.UnbalancedLockRule",
+		"*rules.ShellExecRule",
+		"*rules.IntegerOverflowAllocRule",
+		"*rules.SensitiveErrorRule",
+		"*rules.HardcodedEndpointRule",
 	}
 
 	for _, rule := range analyzer.rules {
@@ -69,6 +239,98 @@ func TestNew(t *testing.T) {
 				found = true
 				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
 				break
+			} else if rule.ID() == rules.NewInsecureJWTRule().ID() && expectedType == "*rules.InsecureJWTRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewInsecureDeserializationRule().ID() && expectedType == "*rules.InsecureDeserializationRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewSecurityMarkerRule().ID() && expectedType == "*rules.SecurityMarkerRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewWeakSessionIDRule().ID() && expectedType == "*rules.WeakSessionIDRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewMultipartLimitRule().ID() && expectedType == "*rules.MultipartLimitRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewInsecureTempFileRule().ID() && expectedType == "*rules.InsecureTempFileRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewInsecureRandomRule().ID() && expectedType == "*rules.InsecureRandomRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewInsecureDBConnectionRule().ID() && expectedType == "*rules.InsecureDBConnectionRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewInsecureFilePermsRule().ID() && expectedType == "*rules.InsecureFilePermsRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewHardcodedKeyMaterialRule().ID() && expectedType == "*rules.HardcodedKeyMaterialRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewHighComplexityRule().ID() && expectedType == "*rules.HighComplexityRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewAbruptTerminationRule().ID() && expectedType == "*rules.AbruptTerminationRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewOpenRedirectRule().ID() && expectedType == "*rules.OpenRedirectRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewSSRFRule().ID() && expectedType == "*rules.SSRFRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewMissingSecurityHeadersRule().ID() && expectedType == "*rules.MissingSecurityHeadersRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewUnusedCancelRule().ID() && expectedType == "*rules.UnusedCancelRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewUnboundedBodyReadRule().ID() && expectedType == "*rules.UnboundedBodyReadRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewDeprecatedIoutilRule().ID() && expectedType == "*rules.DeprecatedIoutilRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewUnbalancedLockRule().ID() && expectedType == "*rules.UnbalancedLockRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewShellExecRule().ID() && expectedType == "*rules.ShellExecRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewIntegerOverflowAllocRule().ID() && expectedType == "*rules.IntegerOverflowAllocRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewSensitiveErrorRule().ID() && expectedType == "*rules.SensitiveErrorRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
+			} else if rule.ID() == rules.NewHardcodedEndpointRule().ID() && expectedType == "*rules.HardcodedEndpointRule" {
+				found = true
+				expectedRuleTypes = append(expectedRuleTypes[:i], expectedRuleTypes[i+1:]...)
+				break
 			}
 		}
 
@@ -299,12 +561,1070 @@ func main() {
 	}
 }
 
+// TestFilesProcessed проверяет счетчики обработанных файлов при параллельном анализе,
+// включая исключенные файлы и файлы с ошибками разбора
+func TestFilesProcessed(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var filePaths []string
+	for dir := 0; dir < 12; dir++ {
+		pkgDir := filepath.Join(tempDir, fmt.Sprintf("pkg%d", dir))
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			t.Fatalf("Ошибка создания директории: %v", err)
+		}
+		for i := 0; i < 3; i++ {
+			fileName := filepath.Join(pkgDir, fmt.Sprintf("file%d.go", i))
+			content := fmt.Sprintf("package pkg%d\n\nfunc f%d() {}\n", dir, i)
+			if i == 2 {
+				content = "package broken\n\nfunc {"
+			}
+			if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+				t.Fatalf("Ошибка создания тестового файла: %v", err)
+			}
+			filePaths = append(filePaths, fileName)
+		}
+	}
+	excluded := filepath.Join(tempDir, "vendor", "lib.go")
+	if err := os.MkdirAll(filepath.Dir(excluded), 0755); err != nil {
+		t.Fatalf("Ошибка создания директории: %v", err)
+	}
+	if err := os.WriteFile(excluded, []byte("package lib\n"), 0644); err != nil {
+		t.Fatalf("Ошибка создания тестового файла: %v", err)
+	}
+	filePaths = append(filePaths, excluded)
+
+	cfg := config.DefaultConfig()
+	cfg.Exclude = []string{"vendor"}
+
+	analyzer := New(cfg, WithProgress(5))
+	if _, err := analyzer.AnalyzePackages(filePaths); err != nil {
+		t.Fatalf("Ошибка анализа пакетов: %v", err)
+	}
+	if processed, total := analyzer.FilesProcessed(); processed != len(filePaths) || total != len(filePaths) {
+		t.Errorf("FilesProcessed() = %d, %d, ожидалось %d, %d", processed, total, len(filePaths), len(filePaths))
+	}
+
+	// Счетчики накапливаются между вызовами
+	if _, err := analyzer.AnalyzeFiles(filePaths[:10]); err != nil {
+		t.Fatalf("Ошибка анализа файлов: %v", err)
+	}
+	if processed, total := analyzer.FilesProcessed(); processed != len(filePaths)+10 || total != len(filePaths)+10 {
+		t.Errorf("FilesProcessed() = %d, %d, ожидалось %d, %d", processed, total, len(filePaths)+10, len(filePaths)+10)
+	}
+}
+
+// TestConcurrencyLimit проверяет, что последовательный анализ дает тот же результат, что и параллельный
+func TestConcurrencyLimit(t *testing.T) {
+	analyze := func(concurrency int) []report.Issue {
+		cfg := config.DefaultConfig()
+		cfg.Concurrency = concurrency
+		// Файлы корпуса анализируются параллельно, результат собирается в порядке входного списка
+		issues, err := New(cfg).AnalyzeFiles(benchCorpus)
+		if err != nil {
+			t.Fatalf("Ошибка анализа с concurrency=%d: %v", concurrency, err)
+		}
+		return issues
+	}
+
+	sequential := analyze(1)
+	if len(sequential) == 0 {
+		t.Fatal("Ожидались проблемы в корпусе")
+	}
+	if parallel := analyze(8); !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("Результаты различаются: последовательно %d проблем, параллельно %d", len(sequential), len(parallel))
+	}
+
+	for _, tc := range []struct {
+		value    int
+		expected int
+	}{
+		{value: 4, expected: 4},
+		{value: 0, expected: 1},
+		{value: -3, expected: 1},
+	} {
+		cfg := config.DefaultConfig()
+		cfg.Concurrency = tc.value
+		if got := New(cfg).concurrency(); got != tc.expected {
+			t.Errorf("concurrency() при Concurrency=%d = %d, ожидалось %d", tc.value, got, tc.expected)
+		}
+	}
+}
+
+// TestAnalysisErrors проверяет, что файл с синтаксической ошибкой учитывается как пропущенный
+func TestAnalysisErrors(t *testing.T) {
+	tempDir := t.TempDir()
+
+	valid := filepath.Join(tempDir, "valid.go")
+	invalid := filepath.Join(tempDir, "invalid.go")
+	if err := os.WriteFile(valid, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatalf("Ошибка создания тестового файла: %v", err)
+	}
+	if err := os.WriteFile(invalid, []byte("package main\n\nfunc main() {\n"), 0644); err != nil {
+		t.Fatalf("Ошибка создания тестового файла: %v", err)
+	}
+
+	analyze := map[string]func(*Analyzer, []string) error{
+		"AnalyzeFiles": func(a *Analyzer, paths []string) error {
+			_, err := a.AnalyzeFiles(paths)
+			return err
+		},
+		"AnalyzePackages": func(a *Analyzer, paths []string) error {
+			_, err := a.AnalyzePackages(paths)
+			return err
+		},
+	}
+
+	for name, fn := range analyze {
+		t.Run(name, func(t *testing.T) {
+			a := New(config.DefaultConfig())
+			if err := fn(a, []string{valid, invalid}); err != nil {
+				t.Fatalf("Ошибка анализа: %v", err)
+			}
+
+			errs := a.AnalysisErrors()
+			if len(errs) != 1 {
+				t.Fatalf("Ожидалась 1 ошибка анализа, получено %d: %v", len(errs), errs)
+			}
+			if errs[0].FilePath != invalid || errs[0].Err == nil {
+				t.Errorf("Ошибка анализа = %+v, ожидался файл %s", errs[0], invalid)
+			}
+		})
+	}
+}
+
+// TestSkipGenerated проверяет, что файлы с заголовком "Code generated ... DO NOT EDIT." не анализируются
+func TestSkipGenerated(t *testing.T) {
+	tempDir := t.TempDir()
+
+	source := "package main\n\nimport \"database/sql\"\n\n" +
+		"func query(db *sql.DB, name string) {\n\tdb.Exec(\"DELETE FROM users WHERE name = '\" + name + \"'\")\n}\n"
+	handwritten := filepath.Join(tempDir, "query.go")
+	generated := filepath.Join(tempDir, "query.pb.go")
+	if err := os.WriteFile(handwritten, []byte(source), 0644); err != nil {
+		t.Fatalf("Ошибка создания тестового файла: %v", err)
+	}
+	generatedSource := "// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: query.proto\n\n" +
+		strings.Replace(source, "func query", "func generatedQuery", 1)
+	if err := os.WriteFile(generated, []byte(generatedSource), 0644); err != nil {
+		t.Fatalf("Ошибка создания тестового файла: %v", err)
+	}
+
+	analyze := map[string]func(*Analyzer, []string) ([]report.Issue, error){
+		"AnalyzeFiles":    (*Analyzer).AnalyzeFiles,
+		"AnalyzePackages": (*Analyzer).AnalyzePackages,
+	}
+
+	for name, fn := range analyze {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.EnabledRules = []string{"SEC001"}
+
+			issues, err := fn(New(cfg), []string{handwritten, generated})
+			if err != nil {
+				t.Fatalf("Ошибка анализа: %v", err)
+			}
+			if len(issues) != 1 || issues[0].FilePath != handwritten {
+				t.Errorf("Ожидалась 1 проблема в %s, получено: %+v", handwritten, issues)
+			}
+
+			// При отключенном skipGenerated сгенерированный файл анализируется
+			cfg.SkipGenerated = false
+			issues, err = fn(New(cfg), []string{handwritten, generated})
+			if err != nil {
+				t.Fatalf("Ошибка анализа: %v", err)
+			}
+			if len(issues) != 2 {
+				t.Errorf("Ожидалось 2 проблемы без skipGenerated, получено: %+v", issues)
+			}
+		})
+	}
+}
+
+// TestCache проверяет, что повторный анализ неизмененных файлов берет результат из кэша,
+// а изменение файла или версии инструмента делает запись недействительной
+func TestCache(t *testing.T) {
+	source := "package main\n\nimport \"database/sql\"\n\n" +
+		"func query(db *sql.DB, name string) {\n\tdb.Exec(\"DELETE FROM users WHERE name = '\" + name + \"'\")\n" +
+		"\tdb.Exec(\"DELETE FROM orders WHERE name = '\" + name + \"'\") // goaudit:ignore SEC001\n}\n"
+
+	analyze := map[string]func(*Analyzer, []string) ([]report.Issue, error){
+		"AnalyzeFiles":    (*Analyzer).AnalyzeFiles,
+		"AnalyzePackages": (*Analyzer).AnalyzePackages,
+	}
+
+	for name, fn := range analyze {
+		t.Run(name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			cacheDir := filepath.Join(tempDir, "cache")
+			filePath := filepath.Join(tempDir, "query.go")
+			if err := os.WriteFile(filePath, []byte(source), 0644); err != nil {
+				t.Fatalf("Ошибка создания тестового файла: %v", err)
+			}
+
+			cfg := config.DefaultConfig()
+			cfg.EnabledRules = []string{"SEC001"}
+			run := func(version string) (*Analyzer, []report.Issue) {
+				t.Helper()
+				a := New(cfg, WithCache(cacheDir, version))
+				issues, err := fn(a, []string{filePath})
+				if err != nil {
+					t.Fatalf("Ошибка анализа: %v", err)
+				}
+				return a, issues
+			}
+
+			first, firstIssues := run("1.0.0")
+			if hits, misses := first.CacheStats(); hits != 0 || misses != 1 {
+				t.Errorf("Первый запуск: попаданий %d, промахов %d, ожидалось 0 и 1", hits, misses)
+			}
+			if len(firstIssues) != 1 {
+				t.Fatalf("Ожидалась 1 проблема, получено %d", len(firstIssues))
+			}
+
+			second, secondIssues := run("1.0.0")
+			if hits, misses := second.CacheStats(); hits != 1 || misses != 0 {
+				t.Errorf("Повторный запуск: попаданий %d, промахов %d, ожидалось 1 и 0", hits, misses)
+			}
+			if !reflect.DeepEqual(secondIssues, firstIssues) {
+				t.Errorf("Проблемы из кэша не совпадают с исходными:\n%+v\n%+v", secondIssues, firstIssues)
+			}
+			if !reflect.DeepEqual(second.SuppressionStats(), first.SuppressionStats()) {
+				t.Errorf("Статистика подавления из кэша = %v, ожидалось %v", second.SuppressionStats(), first.SuppressionStats())
+			}
+
+			// Другая версия инструмента не использует записи предыдущей
+			other, _ := run("1.1.0")
+			if hits, _ := other.CacheStats(); hits != 0 {
+				t.Error("Записи кэша другой версии не должны использоваться")
+			}
+
+			// Изменение файла делает запись недействительной
+			edited := strings.Replace(source, "// goaudit:ignore SEC001", "", 1)
+			if err := os.WriteFile(filePath, []byte(edited), 0644); err != nil {
+				t.Fatalf("Ошибка записи тестового файла: %v", err)
+			}
+			third, thirdIssues := run("1.0.0")
+			if hits, misses := third.CacheStats(); hits != 0 || misses != 1 {
+				t.Errorf("После изменения файла: попаданий %d, промахов %d, ожидалось 0 и 1", hits, misses)
+			}
+			if len(thirdIssues) != 2 {
+				t.Errorf("После изменения файла ожидалось 2 проблемы, получено %d", len(thirdIssues))
+			}
+		})
+	}
+}
+
+// TestAnalyzeFilesContextCancel проверяет, что после отмены новые файлы не запускаются на анализ
+func TestAnalyzeFilesContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var checked atomic.Int32
+	slow := &mockRule{id: "ORG010", onCheck: func() {
+		if checked.Add(1) == 3 {
+			cancel()
+		}
+		time.Sleep(20 * time.Millisecond)
+	}}
+
+	cfg := config.DefaultConfig()
+	cfg.Concurrency = 2
+	analyzer := New(cfg, WithRules(slow))
+
+	start := time.Now()
+	_, err := analyzer.AnalyzeFilesContext(ctx, benchCorpus)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Ожидалась ошибка context.Canceled, получено %v", err)
+	}
+	// Без отмены анализ корпуса занял бы не меньше len(benchCorpus)*20ms/2
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("Анализ после отмены занял %v", elapsed)
+	}
+	if n := int(checked.Load()); n >= len(benchCorpus) {
+		t.Errorf("Проверено %d файлов из %d, ожидалась остановка после отмены", n, len(benchCorpus))
+	}
+
+	// Отмененный контекст не запускает ни одного пакета
+	issues, err := New(cfg).AnalyzePackagesContext(ctx, benchCorpus)
+	if !errors.Is(err, context.Canceled) || len(issues) != 0 {
+		t.Errorf("AnalyzePackagesContext() = %d проблем, %v, ожидалось 0, context.Canceled", len(issues), err)
+	}
+}
+
+// TestSuppressionStats проверяет учет подавленных проблем по правилам и механизмам
+func TestSuppressionStats(t *testing.T) {
+	analyzer := New(config.DefaultConfig())
+
+	sqlIssue := report.Issue{RuleID: "SEC001", FilePath: "a.go", Line: 1}
+	secretIssue := report.Issue{RuleID: "SEC002", FilePath: "b.go", Line: 2}
+
+	analyzer.recordSuppressed(sqlIssue, SuppressionInline)
+	analyzer.recordSuppressed(sqlIssue, SuppressionInline)
+	analyzer.recordSuppressed(sqlIssue, SuppressionBaseline)
+	analyzer.recordSuppressed(secretIssue, SuppressionFile)
+	analyzer.recordSuppressed(secretIssue, SuppressionPath)
+
+	stats := analyzer.SuppressionStats()
+
+	expected := map[string]map[SuppressionMechanism]int{
+		"SEC001": {SuppressionInline: 2, SuppressionBaseline: 1},
+		"SEC002": {SuppressionFile: 1, SuppressionPath: 1},
+	}
+
+	for ruleID, byMechanism := range expected {
+		for mechanism, count := range byMechanism {
+			if stats[ruleID][mechanism] != count {
+				t.Errorf("stats[%s][%s] = %d, ожидалось %d", ruleID, mechanism, stats[ruleID][mechanism], count)
+			}
+		}
+	}
+
+	if stats.Total() != 5 {
+		t.Errorf("Total() = %d, ожидалось 5", stats.Total())
+	}
+
+	if len(analyzer.SuppressedIssues()) != 5 {
+		t.Errorf("len(SuppressedIssues()) = %d, ожидалось 5", len(analyzer.SuppressedIssues()))
+	}
+}
+
+// TestSuppressionStatsReset проверяет, что статистика подавлений относится только к последнему запуску анализа
+func TestSuppressionStatsReset(t *testing.T) {
+	analyzer := New(nil)
+	analyzer.rules = []rules.Rule{
+		&mockRule{id: "SEC001", issues: []report.Issue{{RuleID: "SEC001", Line: 3}}},
+	}
+	code := []byte("package main\n\nfunc a() {} // goaudit:ignore SEC001\n")
+
+	for run := 1; run <= 2; run++ {
+		if _, err := analyzer.AnalyzeSource("main.go", code); err != nil {
+			t.Fatalf("Ошибка анализа: %v", err)
+		}
+		if total := analyzer.SuppressionStats().Total(); total != 1 {
+			t.Errorf("Запуск %d: Total() = %d, ожидалось 1", run, total)
+		}
+		if n := len(analyzer.SuppressedIssues()); n != 1 {
+			t.Errorf("Запуск %d: len(SuppressedIssues()) = %d, ожидалось 1", run, n)
+		}
+	}
+}
+
+// TestIssueProcessor проверяет применение пользовательского обработчика проблем
+func TestIssueProcessor(t *testing.T) {
+	tempFile, err := ioutil.TempFile("", "gosecheck-*.go")
+	if err != nil {
+		t.Fatalf("Ошибка создания временного файла: %v", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write([]byte("package main\n")); err != nil {
+		t.Fatalf("Ошибка записи во временный файл: %v", err)
+	}
+	tempFile.Close()
+
+	dropLow := func(issues []report.Issue) []report.Issue {
+		var filtered []report.Issue
+		for _, issue := range issues {
+			if issue.Severity != report.SeverityLow {
+				filtered = append(filtered, issue)
+			}
+		}
+		return filtered
+	}
+
+	analyzer := New(config.DefaultConfig(), WithIssueProcessor(dropLow))
+	analyzer.rules = []rules.Rule{
+		&mockRule{
+			id:       "MOCK001",
+			severity: report.SeverityLow,
+			issues: []report.Issue{
+				{RuleID: "MOCK001", Severity: report.SeverityLow, Message: "low"},
+				{RuleID: "MOCK001", Severity: report.SeverityHigh, Message: "high"},
+				{RuleID: "MOCK001", Severity: report.SeverityLow, Message: "low"},
+			},
+		},
+	}
+
+	issues, err := analyzer.AnalyzeFiles([]string{tempFile.Name()})
+	if err != nil {
+		t.Fatalf("Ошибка анализа файла: %v", err)
+	}
+
+	if len(issues) != 1 {
+		t.Fatalf("Ожидалась 1 проблема после обработки, получено %d", len(issues))
+	}
+
+	if issues[0].Severity != report.SeverityHigh {
+		t.Errorf("Осталась проблема с серьезностью %s, ожидалось HIGH", issues[0].Severity)
+	}
+}
+
+// TestAnalyzeFilesDetailed проверяет результаты анализа по отдельным файлам
+func TestAnalyzeFilesDetailed(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"clean.go":  "package main\n\nfunc main() {}\n",
+		"vuln.go":   "package main\n\nimport \"database/sql\"\n\nfunc query(db *sql.DB, name string) {\n\tdb.Exec(\"DELETE FROM users WHERE name = '\" + name + \"'\")\n}\n",
+		"broken.go": "package main\n\nfunc main() {\n",
+	}
+	paths := []string{
+		filepath.Join(tempDir, "clean.go"),
+		filepath.Join(tempDir, "vuln.go"),
+		filepath.Join(tempDir, "broken.go"),
+	}
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte(files[filepath.Base(path)]), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	results := New(config.DefaultConfig()).AnalyzeFilesDetailed(paths)
+	if len(results) != len(paths) {
+		t.Fatalf("Ожидалось %d результатов, получено %d", len(paths), len(results))
+	}
+
+	for i, result := range results {
+		if result.Path != paths[i] {
+			t.Errorf("Результат %d относится к %s, ожидалось %s", i, result.Path, paths[i])
+		}
+	}
+
+	if clean := results[0]; clean.Err != nil || clean.Skipped || len(clean.Issues) != 0 {
+		t.Errorf("Чистый файл: ожидался успешный анализ без проблем, получено %+v", clean)
+	}
+
+	if vuln := results[1]; vuln.Err != nil || len(vuln.Issues) == 0 {
+		t.Errorf("Уязвимый файл: ожидались проблемы, получено %+v", vuln)
+	}
+
+	if broken := results[2]; broken.Err == nil || len(broken.Issues) != 0 {
+		t.Errorf("Некорректный файл: ожидалась ошибка разбора, получено %+v", broken)
+	}
+}
+
+// TestWithRulesAddedSince проверяет отбор правил по версии появления
+func TestWithRulesAddedSince(t *testing.T) {
+	all := New(config.DefaultConfig())
+	selected := New(config.DefaultConfig(), WithRulesAddedSince("0.2.0"))
+
+	if len(selected.rules) == 0 || len(selected.rules) >= len(all.rules) {
+		t.Fatalf("Ожидалось непустое подмножество из %d правил, получено %d", len(all.rules), len(selected.rules))
+	}
+
+	for _, rule := range selected.rules {
+		if rule.ID() == rules.NewSQLInjectionRule().ID() {
+			t.Errorf("Правило %s из версии 0.1.0 не должно быть отобрано", rule.ID())
+		}
+	}
+}
+
+// TestSeverityOverrides проверяет применение переопределений серьезности из конфигурации
+func TestSeverityOverrides(t *testing.T) {
+	tempDir := t.TempDir()
+
+	configPath := filepath.Join(tempDir, "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"severityOverrides": {"SEC001": "MEDIUM"}}`), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+
+	filePath := filepath.Join(tempDir, "main.go")
+	code := "package main\n\nimport \"database/sql\"\n\nfunc query(db *sql.DB, name string) {\n\tdb.Exec(\"DELETE FROM users WHERE name = '\" + name + \"'\")\n}\n"
+	if err := os.WriteFile(filePath, []byte(code), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		t.Fatalf("Ошибка загрузки конфигурации: %v", err)
+	}
+
+	issues, err := New(cfg).AnalyzeFiles([]string{filePath})
+	if err != nil {
+		t.Fatalf("Ошибка анализа файла: %v", err)
+	}
+
+	found := false
+	for _, issue := range issues {
+		if issue.RuleID != "SEC001" {
+			continue
+		}
+		found = true
+		if issue.Severity != report.SeverityMedium {
+			t.Errorf("Серьезность SEC001 = %s, ожидалось MEDIUM", issue.Severity)
+		}
+	}
+	if !found {
+		t.Fatal("Не найдены проблемы SEC001")
+	}
+}
+
+// TestRuleSettingsInContext проверяет передачу ruleSettings в контекст правила
+func TestRuleSettingsInContext(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "main.go")
+	code := "package main\n\nfunc main() {\n\tapiKey := \"ab12cd\"\n\t_ = apiKey\n}\n"
+	if err := os.WriteFile(tempFile, []byte(code), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.EnabledRules = []string{"SEC002"}
+
+	issues, err := New(cfg).AnalyzeFiles([]string{tempFile})
+	if err != nil {
+		t.Fatalf("Ошибка анализа файла: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("Без настроек ожидалось 0 проблем, получено %d", len(issues))
+	}
+
+	cfg.RuleSettings["SEC002"] = map[string]interface{}{"minLength": float64(6)}
+
+	issues, err = New(cfg).AnalyzeFiles([]string{tempFile})
+	if err != nil {
+		t.Fatalf("Ошибка анализа файла: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Errorf("С minLength=6 ожидалась 1 проблема, получено %d", len(issues))
+	}
+}
+
+// TestAnalyzePackages проверяет анализ пакета, в котором источник ввода и его использование находятся в разных файлах
+func TestAnalyzePackages(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]string{
+		"a.go": "package handlers\n\nimport \"net/http\"\n\nfunc userName(r *http.Request) string {\n\treturn r.FormValue(\"name\")\n}\n",
+		"b.go": "package handlers\n\nimport (\n\t\"net/http\"\n\t\"os/exec\"\n)\n\nfunc handle(w http.ResponseWriter, r *http.Request) {\n\tname := userName(r)\n\texec.Command(name).Run()\n}\n",
+	}
+	paths := []string{filepath.Join(tempDir, "a.go"), filepath.Join(tempDir, "b.go")}
+	for _, path := range paths {
+		if err := os.WriteFile(path, []byte(files[filepath.Base(path)]), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.EnabledRules = []string{"SEC006"}
+
+	countIn := func(issues []report.Issue, path string) int {
+		count := 0
+		for _, issue := range issues {
+			if issue.FilePath == path {
+				count++
+			}
+		}
+		return count
+	}
+
+	issues, err := New(cfg).AnalyzeFiles(paths)
+	if err != nil {
+		t.Fatalf("Ошибка анализа файлов: %v", err)
+	}
+	if n := countIn(issues, paths[1]); n != 0 {
+		t.Errorf("При пофайловом анализе ожидалось 0 проблем в b.go, получено %d", n)
+	}
+
+	issues, err = New(cfg).AnalyzePackages(paths)
+	if err != nil {
+		t.Fatalf("Ошибка анализа пакетов: %v", err)
+	}
+	if n := countIn(issues, paths[1]); n != 1 {
+		t.Errorf("При анализе пакета ожидалась 1 проблема в b.go, получено %d", n)
+	}
+}
+
+// TestAnalyzeDeterministicOrder проверяет, что порядок проблем до сортировки совпадает с порядком входных файлов
+// и не меняется между запусками при параллельном анализе
+func TestAnalyzeDeterministicOrder(t *testing.T) {
+	tempDir := t.TempDir()
+	code := "package main\n\nimport \"database/sql\"\n\nfunc query(db *sql.DB, name string) {\n" +
+		"\tdb.Exec(\"DELETE FROM users WHERE name = '\" + name + \"'\")\n" +
+		"\tdb.Query(\"SELECT * FROM users WHERE name = '\" + name + \"'\")\n}\n"
+
+	// Директории перечислены в обратном алфавитном порядке, чтобы порядок входа отличался от сортировки
+	var paths []string
+	for i := 15; i >= 0; i-- {
+		dir := filepath.Join(tempDir, fmt.Sprintf("pkg%02d", i))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Ошибка создания директории: %v", err)
+		}
+		path := filepath.Join(dir, "query.go")
+		if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+		paths = append(paths, path)
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.EnabledRules = []string{"SEC001"}
+	cfg.Concurrency = 8
+
+	order := func(issues []report.Issue) []string {
+		keys := make([]string, len(issues))
+		for i, issue := range issues {
+			keys[i] = fmt.Sprintf("%s:%d:%s", issue.FilePath, issue.Line, issue.RuleID)
+		}
+		return keys
+	}
+
+	analyses := map[string]func([]string) ([]report.Issue, error){
+		"files":    New(cfg).AnalyzeFiles,
+		"packages": New(cfg).AnalyzePackages,
+	}
+	for name, analyze := range analyses {
+		t.Run(name, func(t *testing.T) {
+			first, err := analyze(paths)
+			if err != nil {
+				t.Fatalf("Ошибка анализа: %v", err)
+			}
+			if len(first) != 2*len(paths) {
+				t.Fatalf("Ожидалось %d проблем, получено %d", 2*len(paths), len(first))
+			}
+
+			// Проблемы файлов идут в порядке входного списка
+			for i, issue := range first {
+				if issue.FilePath != paths[i/2] {
+					t.Fatalf("Проблема %d в файле %s, ожидался %s", i, issue.FilePath, paths[i/2])
+				}
+			}
+
+			for run := 0; run < 3; run++ {
+				again, err := analyze(paths)
+				if err != nil {
+					t.Fatalf("Ошибка повторного анализа: %v", err)
+				}
+				if !reflect.DeepEqual(order(again), order(first)) {
+					t.Fatalf("Порядок проблем отличается между запусками:\n%v\n%v", order(first), order(again))
+				}
+			}
+		})
+	}
+}
+
+// TestInlineSuppression проверяет подавление проблем директивой goaudit:ignore
+func TestInlineSuppression(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		issueLine  int
+		wantIssues []string
+		wantUnused []string
+	}{
+		{
+			name:       "Та же строка",
+			code:       "package main\n\nvar x = 1 // goaudit:ignore SEC001\n",
+			issueLine:  3,
+			wantIssues: []string{"SEC002"},
+		},
+		{
+			name:       "Строка выше",
+			code:       "package main\n\n// goaudit:ignore SEC001 ложное срабатывание\nvar x = 1\n",
+			issueLine:  4,
+			wantIssues: []string{"SEC002"},
+		},
+		{
+			name:       "Несколько правил",
+			code:       "package main\n\n// goaudit:ignore SEC001,SEC002\nvar x = 1\n",
+			issueLine:  4,
+			wantIssues: nil,
+		},
+		{
+			name:       "Все правила",
+			code:       "package main\n\n// goaudit:ignore\nvar x = 1\n",
+			issueLine:  4,
+			wantIssues: nil,
+		},
+		{
+			name:       "Другая строка",
+			code:       "package main\n\n// goaudit:ignore SEC001\n\nvar x = 1\n",
+			issueLine:  5,
+			wantIssues: []string{"SEC001", "SEC002"},
+			wantUnused: []string{"SEC001"},
+		},
+		{
+			name:       "Строка под комментарием в конце строки",
+			code:       "package main\n\nvar x = 1 // goaudit:ignore SEC001\nvar y = 2\n",
+			issueLine:  4,
+			wantIssues: []string{"SEC001", "SEC002"},
+			wantUnused: []string{"SEC001"},
+		},
+		{
+			name:       "Неиспользуемое правило",
+			code:       "package main\n\nvar x = 1 // goaudit:ignore SEC001,SEC003\n",
+			issueLine:  3,
+			wantIssues: []string{"SEC002"},
+			wantUnused: []string{"SEC003"},
+		},
+		{
+			name:       "Похожий комментарий",
+			code:       "package main\n\nvar x = 1 // goaudit:ignored SEC001\n",
+			issueLine:  3,
+			wantIssues: []string{"SEC001", "SEC002"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := New(nil)
+			analyzer.rules = []rules.Rule{
+				&mockRule{id: "SEC001", issues: []report.Issue{{RuleID: "SEC001", Line: tt.issueLine}}},
+				&mockRule{id: "SEC002", issues: []report.Issue{{RuleID: "SEC002", Line: tt.issueLine}}},
+			}
+
+			issues, err := analyzer.AnalyzeSource("main.go", []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Ошибка анализа: %v", err)
+			}
+
+			var got []string
+			for _, issue := range issues {
+				got = append(got, issue.RuleID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantIssues) {
+				t.Errorf("Проблемы = %v, ожидалось %v", got, tt.wantIssues)
+			}
+
+			if suppressed := len(analyzer.SuppressedIssues()); suppressed != 2-len(tt.wantIssues) {
+				t.Errorf("Подавлено %d проблем, ожидалось %d", suppressed, 2-len(tt.wantIssues))
+			}
+
+			var unused []string
+			for _, u := range analyzer.UnusedSuppressions() {
+				unused = append(unused, u.RuleID)
+			}
+			if fmt.Sprint(unused) != fmt.Sprint(tt.wantUnused) {
+				t.Errorf("Неиспользуемые директивы = %v, ожидалось %v", unused, tt.wantUnused)
+			}
+		})
+	}
+}
+
+// TestFileDirectives проверяет отключение правил директивами goaudit:disable и goaudit:enable
+func TestFileDirectives(t *testing.T) {
+	body := "\nfunc a() {}\n\nfunc b() {}\n\nfunc c() {}\n"
+	tests := []struct {
+		name           string
+		code           string
+		wantIssues     []string
+		wantSuppressed int
+	}{
+		{
+			name:           "Отключение для файла",
+			code:           "//goaudit:disable SEC006 вспомогательная CLI-утилита\n\npackage main\n" + body,
+			wantIssues:     []string{"SEC001:5", "SEC001:9"},
+			wantSuppressed: 2,
+		},
+		{
+			name:           "Все правила для файла",
+			code:           "// goaudit:disable\npackage main\n" + body,
+			wantIssues:     nil,
+			wantSuppressed: 4,
+		},
+		{
+			name:           "Отключение до enable",
+			code:           "package main\n\n//goaudit:disable SEC006\n" + body + "//goaudit:enable SEC006\n",
+			wantIssues:     []string{"SEC001:5", "SEC001:9"},
+			wantSuppressed: 2,
+		},
+		{
+			name:           "Повторное включение",
+			code:           "package main\n\n//goaudit:disable SEC001,SEC006\n\nfunc a() {}\n//goaudit:enable SEC006\n\n\nfunc c() {}\n",
+			wantIssues:     []string{"SEC006:9"},
+			wantSuppressed: 3,
+		},
+		{
+			name:           "Похожий комментарий",
+			code:           "//goaudit:disabled SEC006\npackage main\n" + body,
+			wantIssues:     []string{"SEC001:5", "SEC001:9", "SEC006:5", "SEC006:9"},
+			wantSuppressed: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := New(nil)
+			analyzer.rules = []rules.Rule{
+				&mockRule{id: "SEC001", issues: []report.Issue{{RuleID: "SEC001", Line: 5}, {RuleID: "SEC001", Line: 9}}},
+				&mockRule{id: "SEC006", issues: []report.Issue{{RuleID: "SEC006", Line: 5}, {RuleID: "SEC006", Line: 9}}},
+			}
+
+			issues, err := analyzer.AnalyzeSource("cli.go", []byte(tt.code))
+			if err != nil {
+				t.Fatalf("Ошибка анализа: %v", err)
+			}
+
+			var got []string
+			for _, issue := range issues {
+				got = append(got, fmt.Sprintf("%s:%d", issue.RuleID, issue.Line))
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantIssues) {
+				t.Errorf("Проблемы = %v, ожидалось %v", got, tt.wantIssues)
+			}
+			// Правило, отключенное для всего файла, тоже учитывается в статистике подавлений
+			if n := len(analyzer.SuppressedIssues()); n != tt.wantSuppressed {
+				t.Errorf("Подавлено %d проблем, ожидалось %d", n, tt.wantSuppressed)
+			}
+
+			// Директивы одного файла не действуют на другие файлы
+			other, err := analyzer.AnalyzeSource("other.go", []byte("package main\n"+body))
+			if err != nil {
+				t.Fatalf("Ошибка анализа: %v", err)
+			}
+			if len(other) != 4 {
+				t.Errorf("В файле без директив ожидалось 4 проблемы, получено %d", len(other))
+			}
+		})
+	}
+}
+
+// TestDedupeIssues проверяет удаление одинаковых проблем в одной позиции
+func TestDedupeIssues(t *testing.T) {
+	duplicate := report.Issue{RuleID: "SEC003", FilePath: "main.go", Line: 5, Column: 2, Message: "MD5"}
+	analyzer := New(nil)
+	analyzer.rules = []rules.Rule{
+		&mockRule{id: "SEC003", issues: []report.Issue{
+			duplicate,
+			duplicate,
+			{RuleID: "SEC003", FilePath: "main.go", Line: 5, Column: 2, Message: "SHA1"},
+			{RuleID: "SEC003", FilePath: "main.go", Line: 6, Column: 2, Message: "MD5"},
+		}},
+		&mockRule{id: "SEC004", issues: []report.Issue{{RuleID: "SEC004", FilePath: "main.go", Line: 5, Column: 2, Message: "MD5"}}},
+	}
+
+	issues, err := analyzer.AnalyzeSource("main.go", []byte("package main\n"))
+	if err != nil {
+		t.Fatalf("Ошибка анализа: %v", err)
+	}
+
+	if len(issues) != 4 {
+		t.Fatalf("Ожидалось 4 проблемы, получено %d: %v", len(issues), issues)
+	}
+	count := 0
+	for _, issue := range issues {
+		if issue.RuleID == duplicate.RuleID && issue.Line == duplicate.Line && issue.Message == duplicate.Message {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Повторяющаяся проблема встречается %d раз, ожидалась 1", count)
+	}
+}
+
+// TestRegisterRule проверяет регистрацию дополнительного правила
+func TestRegisterRule(t *testing.T) {
+	t.Cleanup(func() {
+		registryMu.Lock()
+		registeredRules = nil
+		registryMu.Unlock()
+	})
+
+	RegisterRule(&mockRule{
+		id:       "ORG001",
+		severity: report.SeverityHigh,
+		issues:   []report.Issue{{RuleID: "ORG001", Severity: report.SeverityHigh, Line: 1, Message: "org"}},
+	})
+
+	tempFile := filepath.Join(t.TempDir(), "main.go")
+	if err := os.WriteFile(tempFile, []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+
+	countOrg := func(cfg *config.Config) int {
+		issues, err := New(cfg).AnalyzeFiles([]string{tempFile})
+		if err != nil {
+			t.Fatalf("Ошибка анализа файла: %v", err)
+		}
+		count := 0
+		for _, issue := range issues {
+			if issue.RuleID == "ORG001" {
+				count++
+			}
+		}
+		return count
+	}
+
+	if n := countOrg(config.DefaultConfig()); n != 1 {
+		t.Errorf("Ожидалась 1 проблема зарегистрированного правила, получено %d", n)
+	}
+
+	disabled := config.DefaultConfig()
+	disabled.DisabledRules = []string{"ORG001"}
+	if n := countOrg(disabled); n != 0 {
+		t.Errorf("Отключенное правило не должно выдавать проблем, получено %d", n)
+	}
+
+	enabledOnly := config.DefaultConfig()
+	enabledOnly.EnabledRules = []string{"SEC001"}
+	if n := countOrg(enabledOnly); n != 0 {
+		t.Errorf("Правило вне enabledRules не должно выдавать проблем, получено %d", n)
+	}
+
+	for _, id := range []string{"ORG001", "SEC001"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Ожидалась паника при повторной регистрации %s", id)
+				}
+			}()
+			RegisterRule(&mockRule{id: id})
+		}()
+	}
+}
+
+// TestWithRules проверяет добавление правил для отдельного анализатора
+func TestWithRules(t *testing.T) {
+	extra := &mockRule{id: "ORG002"}
+
+	withExtra := New(config.DefaultConfig(), WithRules(extra))
+	plain := New(config.DefaultConfig())
+
+	if len(withExtra.Rules()) != len(plain.Rules())+1 {
+		t.Fatalf("Ожидалось %d правил, получено %d", len(plain.Rules())+1, len(withExtra.Rules()))
+	}
+	if last := withExtra.Rules()[len(withExtra.Rules())-1]; last.ID() != "ORG002" {
+		t.Errorf("Последнее правило %s, ожидалось ORG002", last.ID())
+	}
+	if len(plain.Rules()) != len(DefaultRules()) {
+		t.Errorf("Правила WithRules не должны попадать в другие анализаторы")
+	}
+}
+
+// TestSkipInapplicableRules проверяет, что правило не запускается для файлов без обязательных импортов
+func TestSkipInapplicableRules(t *testing.T) {
+	dir := t.TempDir()
+	plainFile := filepath.Join(dir, "plain.go")
+	cryptoFile := filepath.Join(dir, "hash.go")
+	files := map[string]string{
+		plainFile:  "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(\"md5\") }\n",
+		cryptoFile: "package main\n\nimport \"crypto/md5\"\n\nvar sum = md5.Sum\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	// Правило с требованиями правила SEC005, считающее запуски проверки
+	var checked atomic.Int32
+	rule := &mockRule{
+		id:         "ORG003",
+		applicable: rules.NewInsecureCryptoRule().Applicable,
+		onCheck:    func() { checked.Add(1) },
+	}
+
+	analyzer := New(config.DefaultConfig(), WithRules(rule))
+	if _, err := analyzer.AnalyzeFiles([]string{plainFile}); err != nil {
+		t.Fatalf("Ошибка анализа файла: %v", err)
+	}
+	if n := checked.Load(); n != 0 {
+		t.Errorf("Правило запущено для файла без криптографических импортов %d раз", n)
+	}
+
+	issues, err := analyzer.AnalyzeFiles([]string{plainFile, cryptoFile})
+	if err != nil {
+		t.Fatalf("Ошибка анализа файлов: %v", err)
+	}
+	if n := checked.Load(); n != 1 {
+		t.Errorf("Правило запущено %d раз, ожидался 1 запуск для файла с crypto/md5", n)
+	}
+
+	// Встроенное правило SEC005 находит проблему только в файле с импортом
+	for _, issue := range issues {
+		if issue.RuleID == "SEC005" && issue.FilePath != cryptoFile {
+			t.Errorf("Проблема SEC005 в файле без криптографических импортов: %s", issue.FilePath)
+		}
+	}
+}
+
+// TestPathRuleOverrides проверяет отключение правила для файлов, подходящих под шаблон пути
+func TestPathRuleOverrides(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	code := `package main
+
+import (
+	"net/http"
+	"os"
+)
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	os.Open(r.URL.Query().Get("file"))
+}
+`
+	cmdFile := filepath.Join("cmd", "server", "main.go")
+	apiFile := filepath.Join("internal", "api", "handler.go")
+	for _, path := range []string{cmdFile, apiFile} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Ошибка создания директории: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(code), 0644); err != nil {
+			t.Fatalf("Ошибка записи файла: %v", err)
+		}
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.PathRuleOverrides = []config.PathRuleOverride{{PathGlob: "cmd/**", DisableRules: []string{"SEC006"}}}
+
+	analyzer := New(cfg)
+	issues, err := analyzer.AnalyzeFiles([]string{cmdFile, apiFile})
+	if err != nil {
+		t.Fatalf("Ошибка анализа файлов: %v", err)
+	}
+
+	var apiIssues int
+	for _, issue := range issues {
+		if issue.RuleID != "SEC006" {
+			continue
+		}
+		if issue.FilePath == cmdFile {
+			t.Errorf("Правило SEC006 отключено для cmd/**, но найдена проблема в %s:%d", issue.FilePath, issue.Line)
+		}
+		if issue.FilePath == apiFile {
+			apiIssues++
+		}
+	}
+	if apiIssues == 0 {
+		t.Errorf("Правило SEC006 должно оставаться активным для %s", apiFile)
+	}
+
+	// Проблемы в отключенных путях учитываются в статистике подавления
+	if n := analyzer.SuppressionStats()["SEC006"][SuppressionPath]; n != apiIssues {
+		t.Errorf("Подавлено по пути %d проблем SEC006, ожидалось %d", n, apiIssues)
+	}
+}
+
+// BenchmarkAnalyzeFiles измеряет пропускную способность AnalyzeFiles на корпусе из TestMain
+func BenchmarkAnalyzeFiles(b *testing.B) {
+	// Отключаем отладочное логирование, чтобы измерять только анализ
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.Disabled)
+	defer zerolog.SetGlobalLevel(level)
+
+	analyzer := New(config.DefaultConfig())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := analyzer.AnalyzeFiles(benchCorpus); err != nil {
+			b.Fatalf("Ошибка анализа корпуса: %v", err)
+		}
+	}
+	b.ReportMetric(float64(len(benchCorpus)*b.N)/b.Elapsed().Seconds(), "files/s")
+}
+
 // Мок правила для тестирования
 type mockRule struct {
 	id          string
 	description string
 	severity    report.Severity
 	issues      []report.Issue
+	// Вызывается при каждой проверке файла
+	onCheck func()
+	// Проверка применимости к файлу; nil — правило применимо всегда
+	applicable func(*rules.Context) bool
 }
 
 func (r *mockRule) ID() string {
@@ -319,6 +1639,13 @@ func (r *mockRule) Severity() report.Severity {
 	return r.severity
 }
 
+func (r *mockRule) Applicable(ctx *rules.Context) bool {
+	return r.applicable == nil || r.applicable(ctx)
+}
+
 func (r *mockRule) Check(*rules.Context) []report.Issue {
+	if r.onCheck != nil {
+		r.onCheck()
+	}
 	return r.issues
 }
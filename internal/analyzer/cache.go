@@ -0,0 +1,197 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+	"go-audit/pkg/report"
+)
+
+// issueCache хранит результаты анализа файлов на диске, чтобы не анализировать неизмененные файлы повторно
+type issueCache struct {
+	// Директория файлов кэша
+	dir string
+	// Версия инструмента: результаты другой версии не используются
+	version string
+
+	// Отпечаток версии, набора правил и конфигурации, входящий в каждый ключ
+	saltOnce sync.Once
+	salt     []byte
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// cacheEntry результат анализа файла, сохраненный в кэше.
+// Подавленные проблемы и неиспользуемые директивы сохраняются, чтобы статистика подавления не зависела от кэша.
+type cacheEntry struct {
+	Issues     []report.Issue      `json:"issues"`
+	Suppressed []SuppressedIssue   `json:"suppressed,omitempty"`
+	Unused     []UnusedSuppression `json:"unused,omitempty"`
+}
+
+// WithCache включает кэширование результатов анализа в директории dir.
+// Ключ записи включает путь и содержимое файла (в режиме пакетов — всех файлов директории),
+// версию инструмента, набор правил и конфигурацию, поэтому изменение любого из них делает запись недействительной.
+func WithCache(dir, version string) Option {
+	return func(a *Analyzer) {
+		a.cache = &issueCache{dir: dir, version: version}
+	}
+}
+
+// DefaultCacheDir возвращает директорию кэша по умолчанию в пользовательском кэше ОС
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-audit"), nil
+}
+
+// CacheStats возвращает количество файлов, результаты которых взяты из кэша и вычислены заново
+func (a *Analyzer) CacheStats() (hits, misses int) {
+	if a.cache == nil {
+		return 0, 0
+	}
+	return int(a.cache.hits.Load()), int(a.cache.misses.Load())
+}
+
+// cacheKey вычисляет ключ записи кэша для файла по его содержимому и отпечатку анализатора
+func (a *Analyzer) cacheKey(filePath string, content []byte) string {
+	a.cache.saltOnce.Do(func() {
+		a.cache.salt = a.cacheSalt()
+	})
+
+	h := sha256.New()
+	h.Write(a.cache.salt)
+	h.Write([]byte(filePath))
+	h.Write([]byte{0})
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheSalt вычисляет отпечаток версии, набора правил и конфигурации анализатора
+func (a *Analyzer) cacheSalt() []byte {
+	h := sha256.New()
+	h.Write([]byte(a.cache.version))
+	for _, rule := range a.rules {
+		h.Write([]byte{0})
+		h.Write([]byte(rule.ID()))
+	}
+
+	if a.config != nil {
+		// Количество горутин не влияет на результат анализа
+		cfg := *a.config
+		cfg.Concurrency = 0
+		if data, err := json.Marshal(cfg); err == nil {
+			h.Write([]byte{0})
+			h.Write(data)
+		}
+	}
+	return h.Sum(nil)
+}
+
+// loadCached возвращает проблемы файла из кэша и восстанавливает статистику подавления
+func (a *Analyzer) loadCached(key string) ([]report.Issue, bool) {
+	entry, ok := a.readCached(key)
+	if !ok {
+		a.cache.misses.Add(1)
+		return nil, false
+	}
+	a.replayCached(entry)
+	return entry.Issues, true
+}
+
+// loadCachedPackage возвращает проблемы файлов пакета, если в кэше есть записи для всех файлов.
+// digest — отпечаток содержимого всех файлов директории из packageDigest.
+func (a *Analyzer) loadCachedPackage(files []parsedFile, digest []byte) ([]report.Issue, bool) {
+	entries := make([]cacheEntry, 0, len(files))
+	for _, f := range files {
+		entry, ok := a.readCached(a.cacheKey(f.path, digest))
+		if !ok {
+			a.cache.misses.Add(int64(len(files)))
+			return nil, false
+		}
+		entries = append(entries, entry)
+	}
+
+	var issues []report.Issue
+	for _, entry := range entries {
+		a.replayCached(entry)
+		issues = append(issues, entry.Issues...)
+	}
+	return issues, true
+}
+
+// packageDigest вычисляет отпечаток содержимого всех файлов директории. Он заменяет содержимое файла
+// в ключе кэша, так как правила видят соседние файлы пакета и изменение любого из них меняет результат.
+func packageDigest(files []parsedFile) []byte {
+	h := sha256.New()
+	for _, f := range files {
+		h.Write([]byte(f.path))
+		h.Write([]byte{0})
+		h.Write(f.content)
+		h.Write([]byte{0})
+	}
+	return h.Sum(nil)
+}
+
+// readCached читает запись кэша; отсутствующая или поврежденная запись считается промахом
+func (a *Analyzer) readCached(key string) (cacheEntry, bool) {
+	var entry cacheEntry
+	data, err := os.ReadFile(filepath.Join(a.cache.dir, key+".json"))
+	if err != nil {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		log.Debug().Err(err).Str("key", key).Msg("Поврежденная запись кэша")
+		return entry, false
+	}
+	return entry, true
+}
+
+// replayCached восстанавливает подавления, записанные при анализе файла, и учитывает попадание в кэш
+func (a *Analyzer) replayCached(entry cacheEntry) {
+	a.suppressedMu.Lock()
+	a.suppressed = append(a.suppressed, entry.Suppressed...)
+	a.unusedSuppressions = append(a.unusedSuppressions, entry.Unused...)
+	a.suppressedMu.Unlock()
+
+	a.cache.hits.Add(1)
+}
+
+// storeCached сохраняет проблемы файла и подавления, записанные при его анализе.
+// Ошибка записи не прерывает анализ.
+func (a *Analyzer) storeCached(key, filePath string, issues []report.Issue) {
+	entry := cacheEntry{Issues: issues}
+
+	a.suppressedMu.Lock()
+	for _, s := range a.suppressed {
+		// Базовая линия применяется после анализа и в кэш не попадает
+		if s.Issue.FilePath == filePath && s.Mechanism != SuppressionBaseline {
+			entry.Suppressed = append(entry.Suppressed, s)
+		}
+	}
+	for _, u := range a.unusedSuppressions {
+		if u.FilePath == filePath {
+			entry.Unused = append(entry.Unused, u)
+		}
+	}
+	a.suppressedMu.Unlock()
+
+	data, err := json.Marshal(entry)
+	if err == nil {
+		if err = os.MkdirAll(a.cache.dir, 0755); err == nil {
+			err = os.WriteFile(filepath.Join(a.cache.dir, key+".json"), data, 0644)
+		}
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("dir", a.cache.dir).Msg("Ошибка записи кэша")
+	}
+}
@@ -0,0 +1,39 @@
+package analyzer
+
+import (
+	"sort"
+
+	"go-audit/pkg/report"
+)
+
+// RuleCoverage содержит количество срабатываний правила за время анализа
+type RuleCoverage struct {
+	RuleID string `json:"ruleId"`
+	Hits   int    `json:"hits"`
+}
+
+// RuleCoverage возвращает количество срабатываний каждого включенного правила,
+// включая правила без срабатываний. Результат отсортирован по идентификатору правила.
+func (a *Analyzer) RuleCoverage(issues []report.Issue) []RuleCoverage {
+	hits := make(map[string]int)
+	for _, rule := range a.rules {
+		if a.isRuleEnabled(rule.ID()) {
+			hits[rule.ID()] = 0
+		}
+	}
+
+	for _, issue := range issues {
+		if _, ok := hits[issue.RuleID]; ok {
+			hits[issue.RuleID]++
+		}
+	}
+
+	coverage := make([]RuleCoverage, 0, len(hits))
+	for ruleID, count := range hits {
+		coverage = append(coverage, RuleCoverage{RuleID: ruleID, Hits: count})
+	}
+	sort.Slice(coverage, func(i, j int) bool {
+		return coverage[i].RuleID < coverage[j].RuleID
+	})
+	return coverage
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+
+	"go-audit/pkg/report"
+)
+
+const (
+	// fileDisableDirective отключает правила от строки директивы до goaudit:enable или конца файла
+	fileDisableDirective = "goaudit:disable"
+	// fileEnableDirective снова включает правила, отключенные goaudit:disable
+	fileEnableDirective = "goaudit:enable"
+)
+
+// disabledRange диапазон строк, в котором правило отключено; end == 0 означает конец файла
+type disabledRange struct {
+	start int
+	end   int
+}
+
+// fileDirectives директивы goaudit:disable и goaudit:enable одного файла
+type fileDirectives struct {
+	ranges map[string][]disabledRange
+}
+
+// collectFileDirectives находит директивы `//goaudit:disable SEC006` и `//goaudit:enable SEC006`.
+// Директива без списка правил действует на все правила.
+func collectFileDirectives(fset *token.FileSet, file *ast.File) *fileDirectives {
+	directives := &fileDirectives{
+		ranges: make(map[string][]disabledRange),
+	}
+	// Начальные строки незакрытых диапазонов
+	open := make(map[string]int)
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			line := fset.Position(comment.Slash).Line
+
+			if ids, ok := parseDirective(comment, fileDisableDirective); ok {
+				if len(ids) == 0 {
+					ids = []string{inlineWildcard}
+				}
+				for _, id := range ids {
+					if _, exists := open[id]; !exists {
+						open[id] = line
+					}
+				}
+				continue
+			}
+
+			if ids, ok := parseDirective(comment, fileEnableDirective); ok {
+				if len(ids) == 0 {
+					// goaudit:enable без списка закрывает все открытые диапазоны
+					for id := range open {
+						ids = append(ids, id)
+					}
+				}
+				for _, id := range ids {
+					if start, exists := open[id]; exists {
+						directives.ranges[id] = append(directives.ranges[id], disabledRange{start: start, end: line})
+						delete(open, id)
+					}
+				}
+			}
+		}
+	}
+
+	for id, start := range open {
+		directives.ranges[id] = append(directives.ranges[id], disabledRange{start: start})
+	}
+
+	return directives
+}
+
+// disablesLine проверяет, отключено ли правило на строке
+func (d *fileDirectives) disablesLine(ruleID string, line int) bool {
+	for _, id := range []string{ruleID, inlineWildcard} {
+		for _, r := range d.ranges[id] {
+			if line >= r.start && (r.end == 0 || line < r.end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyFileDirectives удаляет проблемы из диапазонов строк, в которых правило отключено.
+// Правило, отключенное для всего файла, все равно выполняется, чтобы его проблемы учитывались как подавленные.
+func (a *Analyzer) applyFileDirectives(directives *fileDirectives, issues []report.Issue) []report.Issue {
+	if len(directives.ranges) == 0 {
+		return issues
+	}
+
+	var kept []report.Issue
+	for _, issue := range issues {
+		if directives.disablesLine(issue.RuleID, issue.Line) {
+			a.recordSuppressed(issue, SuppressionFile)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept
+}
@@ -0,0 +1,43 @@
+package analyzer
+
+import "sort"
+
+// AnalysisError описывает файл, пропущенный из-за ошибки чтения или разбора
+type AnalysisError struct {
+	// Путь к файлу
+	FilePath string
+	// Ошибка чтения или разбора
+	Err error
+}
+
+// Error реализует интерфейс error
+func (e AnalysisError) Error() string {
+	return e.FilePath + ": " + e.Err.Error()
+}
+
+// Unwrap возвращает исходную ошибку
+func (e AnalysisError) Unwrap() error {
+	return e.Err
+}
+
+// recordError учитывает файл, пропущенный из-за ошибки
+func (a *Analyzer) recordError(filePath string, err error) {
+	a.errorsMu.Lock()
+	defer a.errorsMu.Unlock()
+
+	a.analysisErrors = append(a.analysisErrors, AnalysisError{FilePath: filePath, Err: err})
+}
+
+// AnalysisErrors возвращает файлы, пропущенные из-за ошибок чтения или разбора,
+// отсортированные по пути
+func (a *Analyzer) AnalysisErrors() []AnalysisError {
+	a.errorsMu.Lock()
+	defer a.errorsMu.Unlock()
+
+	result := make([]AnalysisError, len(a.analysisErrors))
+	copy(result, a.analysisErrors)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FilePath < result[j].FilePath
+	})
+	return result
+}
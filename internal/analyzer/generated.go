@@ -0,0 +1,32 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// generatedRegex соответствует стандартному заголовку сгенерированного файла Go:
+// https://go.dev/s/generatedcode
+var generatedRegex = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// isGenerated проверяет, содержит ли файл заголовок "// Code generated ... DO NOT EDIT." до объявления пакета
+func isGenerated(content []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if generatedRegex.MatchString(line) {
+			return true
+		}
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+	}
+	return false
+}
+
+// skipGenerated проверяет, нужно ли пропускать сгенерированные файлы; без конфигурации они пропускаются
+func (a *Analyzer) skipGenerated() bool {
+	return a.config == nil || a.config.SkipGenerated
+}
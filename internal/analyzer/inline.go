@@ -0,0 +1,178 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// inlineIgnoreDirective директива подавления проблем в комментарии
+const inlineIgnoreDirective = "goaudit:ignore"
+
+// inlineWildcard обозначает подавление всех правил директивой без списка идентификаторов
+const inlineWildcard = "*"
+
+// UnusedSuppression представляет директиву подавления, которая не скрыла ни одной проблемы
+type UnusedSuppression struct {
+	FilePath string `json:"filePath"`
+	Line     int    `json:"line"`
+	// Идентификатор правила или "*" для директивы без списка правил
+	RuleID string `json:"ruleId"`
+}
+
+// inlineSuppression директива `// goaudit:ignore` в исходном коде
+type inlineSuppression struct {
+	line int
+	// Комментарий занимает строку целиком; только такая директива действует на следующую строку
+	standalone bool
+	// Правила, указанные в директиве, и признак того, что правило было подавлено
+	rules map[string]bool
+}
+
+// matches проверяет, подавляет ли директива проблему, и отмечает использованное правило.
+// Директива действует на свою строку, а директива на отдельной строке - и на строку непосредственно под ней.
+func (s *inlineSuppression) matches(issue report.Issue) bool {
+	if issue.Line != s.line && (!s.standalone || issue.Line != s.line+1) {
+		return false
+	}
+
+	for _, id := range []string{issue.RuleID, inlineWildcard} {
+		if _, ok := s.rules[id]; ok {
+			s.rules[id] = true
+			return true
+		}
+	}
+	return false
+}
+
+// collectInlineSuppressions находит директивы подавления в комментариях разобранного файла.
+// Формат: `// goaudit:ignore` для всех правил или `// goaudit:ignore SEC001,SEC002 причина`.
+func collectInlineSuppressions(fset *token.FileSet, file *ast.File, content []byte) []*inlineSuppression {
+	var suppressions []*inlineSuppression
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			ids, ok := parseDirective(comment, inlineIgnoreDirective)
+			if !ok {
+				continue
+			}
+
+			pos := fset.Position(comment.Slash)
+			suppression := &inlineSuppression{
+				line:       pos.Line,
+				standalone: isStandaloneComment(content, pos),
+				rules:      make(map[string]bool),
+			}
+			for _, id := range ids {
+				suppression.rules[id] = false
+			}
+			if len(suppression.rules) == 0 {
+				suppression.rules[inlineWildcard] = false
+			}
+
+			suppressions = append(suppressions, suppression)
+		}
+	}
+
+	return suppressions
+}
+
+// isStandaloneComment проверяет, что перед комментарием в его строке только пробельные символы
+func isStandaloneComment(content []byte, pos token.Position) bool {
+	start := pos.Offset - (pos.Column - 1)
+	if start < 0 || pos.Offset > len(content) {
+		return false
+	}
+	return strings.TrimSpace(string(content[start:pos.Offset])) == ""
+}
+
+// parseDirective разбирает комментарий вида `// <directive> SEC001,SEC002 пояснение`
+// и возвращает перечисленные идентификаторы правил. Первое слово после директивы -
+// список правил через запятую, остальное - пояснение.
+func parseDirective(comment *ast.Comment, directive string) ([]string, bool) {
+	text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+	if !strings.HasPrefix(text, directive) {
+		return nil, false
+	}
+
+	rest := strings.TrimPrefix(text, directive)
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false
+	}
+
+	var ids []string
+	if fields := strings.Fields(rest); len(fields) > 0 {
+		for _, id := range strings.Split(fields[0], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids, true
+}
+
+// applyInlineSuppressions удаляет проблемы, подавленные директивами в комментариях файла,
+// и запоминает директивы, которые ничего не подавили
+func (a *Analyzer) applyInlineSuppressions(fset *token.FileSet, file *ast.File, filePath string, content []byte, issues []report.Issue) []report.Issue {
+	suppressions := collectInlineSuppressions(fset, file, content)
+	if len(suppressions) == 0 {
+		return issues
+	}
+
+	var kept []report.Issue
+	for _, issue := range issues {
+		suppressed := false
+		for _, suppression := range suppressions {
+			if suppression.matches(issue) {
+				suppressed = true
+				break
+			}
+		}
+
+		if suppressed {
+			a.recordSuppressed(issue, SuppressionInline)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+
+	var unused []UnusedSuppression
+	for _, suppression := range suppressions {
+		for id, used := range suppression.rules {
+			if !used {
+				unused = append(unused, UnusedSuppression{FilePath: filePath, Line: suppression.line, RuleID: id})
+			}
+		}
+	}
+	if len(unused) > 0 {
+		a.suppressedMu.Lock()
+		a.unusedSuppressions = append(a.unusedSuppressions, unused...)
+		a.suppressedMu.Unlock()
+	}
+
+	return kept
+}
+
+// UnusedSuppressions возвращает директивы подавления последнего запуска анализа, которые не скрыли
+// ни одной проблемы, отсортированные по файлу, строке и правилу
+func (a *Analyzer) UnusedSuppressions() []UnusedSuppression {
+	a.suppressedMu.Lock()
+	defer a.suppressedMu.Unlock()
+
+	result := make([]UnusedSuppression, len(a.unusedSuppressions))
+	copy(result, a.unusedSuppressions)
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FilePath != result[j].FilePath {
+			return result[i].FilePath < result[j].FilePath
+		}
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+		return result[i].RuleID < result[j].RuleID
+	})
+	return result
+}
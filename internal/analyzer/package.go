@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"go-audit/pkg/report"
+)
+
+// parsedFile разобранный файл пакета
+type parsedFile struct {
+	path    string
+	file    *ast.File
+	content []byte
+	// Сгенерированный файл участвует в проверке типов пакета, но правила к нему не применяются
+	generated bool
+}
+
+// AnalyzePackages выполняет анализ файлов, сгруппированных по пакетам.
+// Файлы одной директории и одного пакета разбираются и проверяются вместе,
+// поэтому правила видят объявления из соседних файлов через Context.PackageFiles.
+// Файлы с ошибками чтения или разбора пропускаются и доступны через AnalysisErrors.
+func (a *Analyzer) AnalyzePackages(filePaths []string) ([]report.Issue, error) {
+	return a.AnalyzePackagesContext(context.Background(), filePaths)
+}
+
+// AnalyzePackagesContext выполняет анализ файлов, сгруппированных по пакетам, с возможностью отмены.
+// После отмены контекста новые пакеты не запускаются на анализ, а метод дожидается уже начатых
+// и возвращает проблемы обработанных пакетов вместе с ctx.Err().
+func (a *Analyzer) AnalyzePackagesContext(ctx context.Context, filePaths []string) ([]report.Issue, error) {
+	a.resetSuppressions()
+	groups := groupByDir(filePaths)
+
+	var (
+		// Проблемы каждого пакета записываются по его индексу, чтобы порядок результата
+		// не зависел от порядка завершения горутин
+		results   = make([][]report.Issue, len(groups))
+		wg        sync.WaitGroup
+		semaphore = make(chan struct{}, a.concurrency()) // Ограничиваем количество одновременных горутин
+	)
+	a.progress.addTotal(len(filePaths))
+
+	for i, paths := range groups {
+		// Получаем семафор или прекращаем запуск новых пакетов после отмены
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+		}
+		// Отмена могла произойти одновременно с получением семафора
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, paths []string) {
+			defer wg.Done()
+			defer func() { <-semaphore }() // Освобождаем семафор
+
+			results[i] = a.analyzePackage(paths)
+		}(i, paths)
+	}
+
+	wg.Wait()
+
+	// Объединяем проблемы в порядке входного списка
+	var allIssues []report.Issue
+	for _, issues := range results {
+		allIssues = append(allIssues, issues...)
+	}
+	return a.processIssues(allIssues), ctx.Err()
+}
+
+// analyzePackage разбирает файлы одной директории, группирует их по имени пакета и применяет правила
+func (a *Analyzer) analyzePackage(filePaths []string) []report.Issue {
+	fset := token.NewFileSet()
+
+	// Сначала читаем файлы: при неизменном содержимом директории результаты берутся из кэша без разбора
+	var read []parsedFile
+	for _, filePath := range filePaths {
+		// Проверяем, должен ли файл быть исключен
+		if a.config != nil && a.config.ShouldExclude(filePath) {
+			log.Debug().Str("file", filePath).Msg("Файл исключен из анализа")
+			a.progress.fileDone()
+			continue
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Error().Err(err).Str("file", filePath).Msg("Ошибка анализа файла")
+			a.recordError(filePath, err)
+			a.progress.fileDone()
+			continue
+		}
+		read = append(read, parsedFile{path: filePath, content: content})
+	}
+
+	var digest []byte
+	if a.cache != nil && len(read) > 0 {
+		digest = packageDigest(read)
+		if issues, ok := a.loadCachedPackage(read, digest); ok {
+			log.Debug().Str("dir", filepath.Dir(read[0].path)).Msg("Результат анализа пакета взят из кэша")
+			for range read {
+				a.progress.fileDone()
+			}
+			return issues
+		}
+	}
+
+	var packageNames []string
+	packages := make(map[string][]parsedFile)
+
+	for _, r := range read {
+		file, err := parser.ParseFile(fset, r.path, r.content, parser.ParseComments)
+		if err != nil {
+			log.Error().Err(err).Str("file", r.path).Msg("Ошибка анализа файла")
+			a.recordError(r.path, err)
+			a.progress.fileDone()
+			continue
+		}
+
+		name := file.Name.Name
+		if _, ok := packages[name]; !ok {
+			packageNames = append(packageNames, name)
+		}
+		packages[name] = append(packages[name], parsedFile{
+			path:      r.path,
+			file:      file,
+			content:   r.content,
+			generated: a.skipGenerated() && isGenerated(r.content),
+		})
+	}
+
+	var issues []report.Issue
+	for _, name := range packageNames {
+		parsed := packages[name]
+
+		files := make([]*ast.File, len(parsed))
+		for i, p := range parsed {
+			files[i] = p.file
+		}
+		info := a.typeCheck(fset, files)
+
+		for _, p := range parsed {
+			var fileIssues []report.Issue
+			if p.generated {
+				log.Debug().Str("file", p.path).Msg("Сгенерированный файл пропущен")
+			} else {
+				fileIssues = a.checkFile(fset, p.file, p.path, p.content, files, info)
+			}
+			if len(fileIssues) > 0 {
+				log.Debug().Str("file", p.path).Int("issues", len(fileIssues)).Msg("Найдены проблемы в файле")
+			}
+			// Файлы с ошибками разбора не сохраняются, поэтому пакет с ними анализируется заново
+			if a.cache != nil {
+				a.storeCached(a.cacheKey(p.path, digest), p.path, fileIssues)
+			}
+			issues = append(issues, fileIssues...)
+			a.progress.fileDone()
+		}
+	}
+
+	return issues
+}
+
+// groupByDir группирует пути файлов по директориям с сохранением порядка первого появления
+func groupByDir(filePaths []string) [][]string {
+	var groups [][]string
+	index := make(map[string]int)
+
+	for _, filePath := range filePaths {
+		dir := filepath.Dir(filePath)
+		i, ok := index[dir]
+		if !ok {
+			i = len(groups)
+			index[dir] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], filePath)
+	}
+
+	return groups
+}
@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// progress содержит счетчики обработанных файлов, общие для параллельно работающих горутин
+type progress struct {
+	// Количество файлов, переданных на анализ
+	total atomic.Int64
+	// Количество файлов, обработка которых завершена, включая исключенные и ошибочные
+	processed atomic.Int64
+	// Интервал вывода прогресса в файлах, 0 отключает вывод
+	every int64
+}
+
+// WithProgress включает вывод прогресса анализа в лог через каждые every обработанных файлов
+func WithProgress(every int) Option {
+	return func(a *Analyzer) {
+		a.progress.every = int64(every)
+	}
+}
+
+// addTotal увеличивает количество файлов, переданных на анализ
+func (p *progress) addTotal(n int) {
+	p.total.Add(int64(n))
+}
+
+// fileDone отмечает завершение обработки файла и при необходимости выводит прогресс
+func (p *progress) fileDone() {
+	processed := p.processed.Add(1)
+	if p.every <= 0 {
+		return
+	}
+
+	total := p.total.Load()
+	if processed%p.every == 0 || processed == total {
+		log.Info().Int64("processed", processed).Int64("total", total).Msg("Прогресс анализа")
+	}
+}
+
+// FilesProcessed возвращает количество обработанных файлов и общее количество файлов,
+// переданных на анализ с момента создания анализатора
+func (a *Analyzer) FilesProcessed() (processed, total int) {
+	return int(a.progress.processed.Load()), int(a.progress.total.Load())
+}
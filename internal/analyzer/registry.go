@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"go-audit/internal/rules"
+)
+
+// Правила, зарегистрированные через RegisterRule
+var (
+	registryMu      sync.RWMutex
+	registeredRules []rules.Rule
+)
+
+// DefaultRules возвращает новые экземпляры встроенных правил
+func DefaultRules() []rules.Rule {
+	return []rules.Rule{
+		rules.NewSQLInjectionRule(),
+		rules.NewHardcodedSecretsRule(),
+		rules.NewInsecureHTTPRule(),
+		rules.NewMissingErrorCheckRule(),
+		rules.NewInsecureCryptoRule(),
+		rules.NewInsecureUserInputRule(),
+		rules.NewInsecureJWTRule(),
+		rules.NewInsecureDeserializationRule(),
+		rules.NewSecurityMarkerRule(),
+		rules.NewWeakSessionIDRule(),
+		rules.NewMultipartLimitRule(),
+		rules.NewInsecureTempFileRule(),
+		rules.NewInsecureRandomRule(),
+		rules.NewInsecureDBConnectionRule(),
+		rules.NewInsecureFilePermsRule(),
+		rules.NewHardcodedKeyMaterialRule(),
+		rules.NewHighComplexityRule(),
+		rules.NewAbruptTerminationRule(),
+		rules.NewOpenRedirectRule(),
+		rules.NewSSRFRule(),
+		rules.NewMissingSecurityHeadersRule(),
+		rules.NewUnusedCancelRule(),
+		rules.NewUnboundedBodyReadRule(),
+		rules.NewDeprecatedIoutilRule(),
+		rules.NewUnbalancedLockRule(),
+		rules.NewShellExecRule(),
+		rules.NewIntegerOverflowAllocRule(),
+		rules.NewSensitiveErrorRule(),
+		rules.NewHardcodedEndpointRule(),
+	}
+}
+
+// RegisterRule регистрирует дополнительное правило для всех анализаторов, создаваемых после вызова.
+// Экземпляр правила используется всеми анализаторами и должен быть безопасен для параллельного вызова Check.
+// Обычно вызывается из init. Паникует, если правило равно nil или его идентификатор уже занят,
+// по аналогии с database/sql.Register.
+func RegisterRule(rule rules.Rule) {
+	if rule == nil {
+		panic("analyzer: RegisterRule: правило равно nil")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, existing := range append(DefaultRules(), registeredRules...) {
+		if existing.ID() == rule.ID() {
+			panic(fmt.Sprintf("analyzer: RegisterRule: правило %s уже зарегистрировано", rule.ID()))
+		}
+	}
+	registeredRules = append(registeredRules, rule)
+}
+
+// WithRules добавляет правила только для создаваемого анализатора
+func WithRules(extra ...rules.Rule) Option {
+	return func(a *Analyzer) {
+		a.rules = append(a.rules, extra...)
+	}
+}
+
+// allRules возвращает встроенные и зарегистрированные правила
+func allRules() []rules.Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return append(DefaultRules(), registeredRules...)
+}
+
+// RuleIDs возвращает отсортированные идентификаторы встроенных и зарегистрированных правил
+func RuleIDs() []string {
+	all := allRules()
+	ids := make([]string, 0, len(all))
+	for _, rule := range all {
+		ids = append(ids, rule.ID())
+	}
+	sort.Strings(ids)
+	return ids
+}
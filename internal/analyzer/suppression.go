@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"sort"
+
+	"go-audit/pkg/report"
+)
+
+// SuppressionMechanism определяет способ, которым была подавлена проблема
+type SuppressionMechanism string
+
+const (
+	// SuppressionInline подавление комментарием в строке с проблемой
+	SuppressionInline SuppressionMechanism = "inline"
+	// SuppressionFile подавление директивой уровня файла
+	SuppressionFile SuppressionMechanism = "file"
+	// SuppressionBaseline подавление файлом базовой линии
+	SuppressionBaseline SuppressionMechanism = "baseline"
+	// SuppressionPath подавление переопределением правил для путей
+	SuppressionPath SuppressionMechanism = "path"
+)
+
+// SuppressedIssue представляет проблему, скрытую одним из механизмов подавления
+type SuppressedIssue struct {
+	Issue     report.Issue         `json:"issue"`
+	Mechanism SuppressionMechanism `json:"mechanism"`
+}
+
+// SuppressionStats содержит количество подавленных проблем по правилам и механизмам
+type SuppressionStats map[string]map[SuppressionMechanism]int
+
+// Total возвращает общее количество подавленных проблем
+func (s SuppressionStats) Total() int {
+	total := 0
+	for _, byMechanism := range s {
+		for _, count := range byMechanism {
+			total += count
+		}
+	}
+	return total
+}
+
+// RuleIDs возвращает отсортированный список правил с подавленными проблемами
+func (s SuppressionStats) RuleIDs() []string {
+	ids := make([]string, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// recordSuppressed учитывает проблему, скрытую механизмом подавления
+func (a *Analyzer) recordSuppressed(issue report.Issue, mechanism SuppressionMechanism) {
+	a.suppressedMu.Lock()
+	defer a.suppressedMu.Unlock()
+
+	a.suppressed = append(a.suppressed, SuppressedIssue{Issue: issue, Mechanism: mechanism})
+}
+
+// resetSuppressions очищает подавления предыдущего запуска, чтобы статистика относилась
+// только к текущему вызову Analyze*
+func (a *Analyzer) resetSuppressions() {
+	a.suppressedMu.Lock()
+	defer a.suppressedMu.Unlock()
+
+	a.suppressed = nil
+	a.unusedSuppressions = nil
+}
+
+// SuppressionStats возвращает статистику подавленных проблем по правилам последнего запуска анализа
+func (a *Analyzer) SuppressionStats() SuppressionStats {
+	a.suppressedMu.Lock()
+	defer a.suppressedMu.Unlock()
+
+	stats := make(SuppressionStats)
+	for _, suppressed := range a.suppressed {
+		if stats[suppressed.Issue.RuleID] == nil {
+			stats[suppressed.Issue.RuleID] = make(map[SuppressionMechanism]int)
+		}
+		stats[suppressed.Issue.RuleID][suppressed.Mechanism]++
+	}
+	return stats
+}
+
+// SuppressedIssues возвращает все проблемы, подавленные в последнем запуске анализа
+func (a *Analyzer) SuppressedIssues() []SuppressedIssue {
+	a.suppressedMu.Lock()
+	defer a.suppressedMu.Unlock()
+
+	result := make([]SuppressedIssue, len(a.suppressed))
+	copy(result, a.suppressed)
+	return result
+}
@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"sync"
+)
+
+// cachingImporter потокобезопасно кеширует результаты импорта пакетов, включая ошибки,
+// чтобы неразрешимые импорты не загружались повторно для каждого файла
+type cachingImporter struct {
+	mu       sync.Mutex
+	importer types.Importer
+	packages map[string]*types.Package
+	errors   map[string]error
+}
+
+// newCachingImporter создает импортер на основе стандартного импортера компилятора
+func newCachingImporter() *cachingImporter {
+	return &cachingImporter{
+		importer: importer.Default(),
+		packages: make(map[string]*types.Package),
+		errors:   make(map[string]error),
+	}
+}
+
+// Import реализует интерфейс types.Importer
+func (i *cachingImporter) Import(path string) (*types.Package, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if pkg, ok := i.packages[path]; ok {
+		return pkg, nil
+	}
+	if err, ok := i.errors[path]; ok {
+		return nil, err
+	}
+
+	pkg, err := i.importer.Import(path)
+	if err != nil {
+		i.errors[path] = err
+		return nil, err
+	}
+	i.packages[path] = pkg
+	return pkg, nil
+}
+
+// typeCheck выполняет проверку типов файлов пакета и возвращает собранную информацию.
+// Ошибки проверки игнорируются: для неразрешенных импортов информация о типах будет неполной,
+// и правила используют сопоставление по именам.
+func (a *Analyzer) typeCheck(fset *token.FileSet, files []*ast.File) *types.Info {
+	if len(files) == 0 {
+		return nil
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+
+	conf := types.Config{
+		Importer: a.importer,
+		Error:    func(error) {},
+	}
+	_, _ = conf.Check(files[0].Name.Name, fset, files, info)
+
+	return info
+}
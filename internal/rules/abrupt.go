@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// AbruptTerminationRule проверяет завершение процесса через os.Exit, log.Fatal и panic
+// в библиотечных пакетах, где решение о завершении должен принимать вызывающий код
+type AbruptTerminationRule struct {
+	BaseRule
+	// Имена функций, которым по соглашению разрешено паниковать
+	panicFuncRegex *regexp.Regexp
+}
+
+// NewAbruptTerminationRule создает новое правило для проверки аварийного завершения в библиотечных пакетах
+func NewAbruptTerminationRule() *AbruptTerminationRule {
+	return &AbruptTerminationRule{
+		BaseRule: BaseRule{
+			id:          "SEC018",
+			description: "Аварийное завершение процесса в библиотечном пакете",
+			severity:    report.SeverityLow,
+			cwe:         "CWE-382",
+			addedIn:     "0.2.0",
+			remediation: "Возвращайте ошибку вызывающему коду вместо завершения процесса в библиотечном пакете",
+		},
+		// Экспортируемые MustXxx, а также Register и RegisterXxx с одним словом после префикса:
+		// Registered, Mustache и RegisterUserHandler под соглашение не подпадают
+		panicFuncRegex: regexp.MustCompile(`^(Must[A-Z]|Register([A-Z][a-z0-9]*)?$)`),
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *AbruptTerminationRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// В main и тестах завершение процесса допустимо
+	if ctx.Package == "main" || strings.HasSuffix(ctx.FilePath, "_test.go") {
+		return issues
+	}
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		// init и TestMain выполняются при запуске и могут завершать процесс
+		if funcDecl.Recv == nil && (funcDecl.Name.Name == "init" || funcDecl.Name.Name == "TestMain") {
+			continue
+		}
+
+		// Must* и Register* по соглашению паникуют при ошибке программиста,
+		// как regexp.MustCompile и database/sql.Register
+		panicAllowed := r.panicFuncRegex.MatchString(funcDecl.Name.Name)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			callExpr, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if name := r.terminationCall(callExpr, ctx); name != "" && !(panicAllowed && name == "panic") {
+				issues = append(issues, r.NewIssue(callExpr.Pos(), ctx,
+					"Вызов "+name+" в пакете "+ctx.Package+" завершает процесс импортирующей программы, возвращайте ошибку вызывающему коду"))
+			}
+			return true
+		})
+	}
+
+	return issues
+}
+
+// terminationCall возвращает имя вызова, завершающего процесс: os.Exit, log.Fatal* или panic
+func (r *AbruptTerminationRule) terminationCall(callExpr *ast.CallExpr, ctx *Context) string {
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		if fun.Name != "panic" {
+			return ""
+		}
+		// Локальная функция с именем panic не является встроенной
+		if ctx.TypesInfo != nil {
+			if _, ok := ctx.TypesInfo.Uses[fun].(*types.Builtin); !ok {
+				return ""
+			}
+		}
+		return "panic"
+
+	case *ast.SelectorExpr:
+		x, ok := fun.X.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+		if x.Name == "os" && fun.Sel.Name == "Exit" {
+			return "os.Exit"
+		}
+		if x.Name == "log" && strings.HasPrefix(fun.Sel.Name, "Fatal") {
+			return "log." + fun.Sel.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+
+	"go-audit/pkg/report"
+)
+
+// IntegerOverflowAllocRule проверяет размеры выделения памяти, вычисляемые арифметикой над пользовательским вводом:
+// make([]byte, n*m) при переполнении выделяет меньше памяти, чем ожидает последующий код
+type IntegerOverflowAllocRule struct {
+	BaseRule
+	// Арифметические операции, результат которых может переполниться
+	overflowOps map[token.Token]bool
+}
+
+// NewIntegerOverflowAllocRule создает новое правило для проверки переполнения при вычислении размера выделения
+func NewIntegerOverflowAllocRule() *IntegerOverflowAllocRule {
+	return &IntegerOverflowAllocRule{
+		BaseRule: BaseRule{
+			id:          "SEC027",
+			description: "Возможное переполнение целого при вычислении размера выделения",
+			severity:    report.SeverityLow,
+			cwe:         "CWE-190",
+			addedIn:     "0.2.0",
+			remediation: "Проверяйте границы значений из пользовательского ввода до вычисления размера выделения",
+		},
+		overflowOps: map[token.Token]bool{
+			token.MUL: true,
+			token.ADD: true,
+			token.SUB: true,
+			token.SHL: true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *IntegerOverflowAllocRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	tracker := NewTaintTracker(ctx)
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		tainted := tracker.Propagate(funcDecl.Body)
+		checked := collectBoundsChecks(funcDecl.Body)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			var sizes []ast.Expr
+			var subject, consequence string
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				// Размер и емкость make: make([]T, len, cap)
+				if ident, ok := node.Fun.(*ast.Ident); ok && ident.Name == "make" && len(node.Args) > 1 {
+					sizes = node.Args[1:]
+					subject, consequence = "Размер make", "выделению меньшего объема памяти"
+				}
+			case *ast.SliceExpr:
+				// Границы среза: buf[:n*m] или buf[a:b:n*m]
+				sizes = []ast.Expr{node.High, node.Max}
+				subject, consequence = "Граница среза", "срезу неверной длины"
+			}
+
+			for _, size := range sizes {
+				if size == nil || !r.isOverflowArithmetic(size) {
+					continue
+				}
+				if !r.hasUncheckedInput(size, tainted, checked, n.Pos()) {
+					continue
+				}
+				issues = append(issues, r.NewIssueRange(size.Pos(), size.End(), ctx,
+					subject+" "+astToString(size)+" вычисляется из пользовательского ввода без проверки границ: "+
+						"переполнение целого приведет к "+consequence))
+				break
+			}
+			return true
+		})
+	}
+
+	return issues
+}
+
+// isOverflowArithmetic проверяет, является ли выражение арифметической операцией, которая может переполниться
+func (r *IntegerOverflowAllocRule) isOverflowArithmetic(expr ast.Expr) bool {
+	for {
+		paren, ok := expr.(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = paren.X
+	}
+
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		// Преобразование типа результата: int(n*m)
+		if _, ok := call.Fun.(*ast.Ident); ok {
+			return r.isOverflowArithmetic(call.Args[0])
+		}
+	}
+
+	bin, ok := expr.(*ast.BinaryExpr)
+	return ok && r.overflowOps[bin.Op]
+}
+
+// hasUncheckedInput проверяет, содержит ли выражение пользовательский ввод, границы которого
+// не проверялись до позиции pos
+func (r *IntegerOverflowAllocRule) hasUncheckedInput(expr ast.Expr, tainted *TaintTracker, checked map[string]token.Pos, pos token.Pos) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		e, ok := n.(ast.Expr)
+		if !ok || found {
+			return !found
+		}
+		// Длина существующего значения ограничена его размером: len(body)+1 не переполняется
+		if call, ok := e.(*ast.CallExpr); ok {
+			if ident, ok := call.Fun.(*ast.Ident); ok && (ident.Name == "len" || ident.Name == "cap") {
+				return false
+			}
+		}
+		if tainted.IsSource(e) {
+			found = true
+			return false
+		}
+		if ident, ok := e.(*ast.Ident); ok && tainted.IsTainted(ident) {
+			if checkPos, ok := checked[ident.Name]; !ok || checkPos > pos {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// collectBoundsChecks находит переменные, сравниваемые в условиях if, и позицию первой такой проверки:
+// if n > maxItems { return } считается проверкой границ n
+func collectBoundsChecks(body *ast.BlockStmt) map[string]token.Pos {
+	checked := make(map[string]token.Pos)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		ifStmt, ok := n.(*ast.IfStmt)
+		if !ok {
+			return true
+		}
+		ast.Inspect(ifStmt.Cond, func(n ast.Node) bool {
+			bin, ok := n.(*ast.BinaryExpr)
+			if !ok {
+				return true
+			}
+			switch bin.Op {
+			case token.LSS, token.GTR, token.LEQ, token.GEQ:
+			default:
+				return true
+			}
+			for _, side := range []ast.Expr{bin.X, bin.Y} {
+				ast.Inspect(side, func(n ast.Node) bool {
+					if ident, ok := n.(*ast.Ident); ok {
+						if _, ok := checked[ident.Name]; !ok {
+							checked[ident.Name] = ifStmt.Pos()
+						}
+					}
+					return true
+				})
+			}
+			return true
+		})
+		return true
+	})
+
+	return checked
+}
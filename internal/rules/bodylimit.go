@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// UnboundedBodyReadRule проверяет HTTP-обработчики, читающие тело запроса целиком без ограничения размера
+type UnboundedBodyReadRule struct {
+	BaseRule
+	// Функции, читающие поток до конца, и индекс аргумента с источником
+	readFuncs map[string]int
+}
+
+// NewUnboundedBodyReadRule создает новое правило для проверки чтения тела запроса без ограничения размера
+func NewUnboundedBodyReadRule() *UnboundedBodyReadRule {
+	return &UnboundedBodyReadRule{
+		BaseRule: BaseRule{
+			id:          "SEC023",
+			description: "Чтение тела запроса без ограничения размера",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-770",
+			addedIn:     "0.2.0",
+			remediation: "Оберните тело запроса в http.MaxBytesReader перед чтением",
+		},
+		readFuncs: map[string]int{
+			"io.ReadAll":     0,
+			"ioutil.ReadAll": 0,
+			"io.Copy":        1,
+			"io.CopyBuffer":  1,
+		},
+	}
+}
+
+// Applicable проверяет, применимо ли правило к файлу: тела запросов читают только HTTP-обработчики
+func (r *UnboundedBodyReadRule) Applicable(ctx *Context) bool {
+	return hasWebFramework(ctx)
+}
+
+// Check реализует интерфейс Rule
+func (r *UnboundedBodyReadRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	httpName := importName(ctx, map[string]bool{"net/http": true})
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		requests := requestParamNames(funcDecl.Type, httpName)
+		limited := collectBodyLimits(funcDecl.Body, httpName)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			argIndex, ok := r.readFuncs[astToString(call.Fun)]
+			if !ok || argIndex >= len(call.Args) {
+				return true
+			}
+
+			body := astToString(call.Args[argIndex])
+			if !isRequestBody(body, requests) {
+				return true
+			}
+			// Ограничение должно быть установлено до чтения
+			if pos, ok := limited[body]; ok && pos < call.Pos() {
+				return true
+			}
+
+			issues = append(issues, r.NewIssue(call.Pos(), ctx,
+				"Тело запроса "+body+" читается целиком без ограничения размера, "+
+					"оберните его в http.MaxBytesReader перед чтением"))
+			return true
+		})
+	}
+
+	return issues
+}
+
+// requestParamNames возвращает имена параметров функции с типом *http.Request
+func requestParamNames(funcType *ast.FuncType, httpName string) map[string]bool {
+	names := make(map[string]bool)
+	if funcType.Params == nil || httpName == "" {
+		return names
+	}
+
+	for _, field := range funcType.Params.List {
+		star, ok := field.Type.(*ast.StarExpr)
+		if !ok || astToString(star.X) != httpName+".Request" {
+			continue
+		}
+		for _, name := range field.Names {
+			names[name.Name] = true
+		}
+	}
+	return names
+}
+
+// isRequestBody проверяет, является ли выражение телом входящего запроса: r.Body для параметра *http.Request
+// или c.Request.Body и c.Request().Body для контекстов веб-фреймворков
+func isRequestBody(expr string, requests map[string]bool) bool {
+	request, ok := strings.CutSuffix(expr, ".Body")
+	if !ok {
+		return false
+	}
+	return requests[request] || strings.HasSuffix(request, ".Request")
+}
+
+// collectBodyLimits находит тела запросов, ограниченные через r.Body = http.MaxBytesReader(w, r.Body, n),
+// и позиции установки ограничения
+func collectBodyLimits(body *ast.BlockStmt, httpName string) map[string]token.Pos {
+	limited := make(map[string]token.Pos)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || astToString(call.Fun) != httpName+".MaxBytesReader" {
+			return true
+		}
+
+		target := astToString(assign.Lhs[0])
+		if _, ok := limited[target]; !ok {
+			limited[target] = assign.Pos()
+		}
+		return true
+	})
+
+	return limited
+}
@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"go/ast"
+
+	"go-audit/pkg/report"
+)
+
+// UnusedCancelRule проверяет функции отмены контекста, которые не вызываются:
+// контекст и его таймер освобождаются только по истечении родительского контекста
+type UnusedCancelRule struct {
+	BaseRule
+	// Функции пакета context, возвращающие функцию отмены вторым результатом
+	cancelFuncs map[string]bool
+}
+
+// NewUnusedCancelRule создает новое правило для проверки невызываемых функций отмены контекста
+func NewUnusedCancelRule() *UnusedCancelRule {
+	return &UnusedCancelRule{
+		BaseRule: BaseRule{
+			id:          "SEC022",
+			description: "Функция отмены контекста не вызывается",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-404",
+			addedIn:     "0.2.0",
+			remediation: "Вызывайте функцию отмены на всех путях выполнения, обычно через defer cancel() сразу после создания контекста",
+		},
+		cancelFuncs: map[string]bool{
+			"WithCancel":        true,
+			"WithCancelCause":   true,
+			"WithTimeout":       true,
+			"WithTimeoutCause":  true,
+			"WithDeadline":      true,
+			"WithDeadlineCause": true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *UnusedCancelRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальное имя пакета context с учетом псевдонима импорта
+	contextName := importName(ctx, map[string]bool{"context": true})
+	if contextName == "" {
+		return issues
+	}
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		// Вызов, передача или возврат функции отмены во вложенных функциях тоже считаются использованием
+		used := collectIdentUses(funcDecl.Body)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			var lhs []ast.Expr
+			var rhs []ast.Expr
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				lhs, rhs = node.Lhs, node.Rhs
+			case *ast.ValueSpec:
+				for _, name := range node.Names {
+					lhs = append(lhs, name)
+				}
+				rhs = node.Values
+			default:
+				return true
+			}
+			if len(lhs) != 2 || len(rhs) != 1 {
+				return true
+			}
+
+			call, ok := rhs[0].(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !r.cancelFuncs[sel.Sel.Name] || !isPackageCall(sel, contextName, sel.Sel.Name) {
+				return true
+			}
+
+			cancel, ok := lhs[1].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			switch {
+			case cancel.Name == "_":
+				issues = append(issues, r.NewIssue(call.Pos(), ctx,
+					"Функция отмены, возвращаемая context."+sel.Sel.Name+", отбрасывается, контекст не будет освобожден до отмены родительского; "+
+						"сохраните ее и вызовите, например defer cancel()"))
+			case cancel.Obj != nil && !used[cancel.Obj]:
+				issues = append(issues, r.NewIssue(call.Pos(), ctx,
+					"Функция отмены "+cancel.Name+", возвращаемая context."+sel.Sel.Name+", не вызывается; "+
+						"вызовите ее на всех путях выполнения, например defer "+cancel.Name+"()"))
+			}
+			return true
+		})
+	}
+
+	return issues
+}
+
+// collectIdentUses находит объекты, на которые ссылаются идентификаторы тела функции, кроме их объявлений
+// и присваиваний им нового значения
+func collectIdentUses(body *ast.BlockStmt) map[*ast.Object]bool {
+	used := make(map[*ast.Object]bool)
+	// Идентификаторы в левой части присваиваний не являются использованием
+	assigned := make(map[*ast.Ident]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range node.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok {
+					assigned[ident] = true
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range node.Names {
+				assigned[name] = true
+			}
+		case *ast.Ident:
+			if node.Obj != nil && !assigned[node] {
+				used[node.Obj] = true
+			}
+		}
+		return true
+	})
+
+	return used
+}
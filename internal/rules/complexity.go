@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"go-audit/pkg/report"
+)
+
+// defaultMaxComplexity порог цикломатической сложности функции по умолчанию
+const defaultMaxComplexity = 15
+
+// HighComplexityRule отмечает функции с высокой цикломатической сложностью,
+// в которых проверки безопасности легко пропустить при ревью
+type HighComplexityRule struct {
+	BaseRule
+}
+
+// NewHighComplexityRule создает новое правило для проверки цикломатической сложности функций
+func NewHighComplexityRule() *HighComplexityRule {
+	return &HighComplexityRule{
+		BaseRule: BaseRule{
+			id:          "SEC017",
+			description: "Функция с высокой цикломатической сложностью",
+			severity:    report.SeverityInfo,
+			cwe:         "CWE-1121",
+			addedIn:     "0.2.0",
+			remediation: "Разбейте функцию на меньшие части, чтобы упростить ее проверку и тестирование",
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *HighComplexityRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	maxComplexity := ctx.IntSetting("maxComplexity", defaultMaxComplexity)
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		complexity := cyclomaticComplexity(funcDecl.Body)
+		if complexity <= maxComplexity {
+			continue
+		}
+
+		issues = append(issues, r.NewIssue(funcDecl.Pos(), ctx,
+			"Цикломатическая сложность функции "+funcDisplayName(funcDecl)+" равна "+strconv.Itoa(complexity)+
+				" при пороге "+strconv.Itoa(maxComplexity)+", разбейте функцию на части"))
+	}
+
+	return issues
+}
+
+// cyclomaticComplexity вычисляет цикломатическую сложность тела функции:
+// единица плюс число ветвлений, циклов, непустых веток switch/select и операторов && и ||
+func cyclomaticComplexity(body *ast.BlockStmt) int {
+	complexity := 1
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if node.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if node.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if node.Op == token.LAND || node.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+
+	return complexity
+}
+
+// funcDisplayName возвращает имя функции, для методов вместе с типом получателя: Server.Handle
+func funcDisplayName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+
+	recv := funcDecl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	// Получатель обобщенного типа: Cache[K, V]
+	switch node := recv.(type) {
+	case *ast.IndexExpr:
+		recv = node.X
+	case *ast.IndexListExpr:
+		recv = node.X
+	}
+	return astToString(recv) + "." + funcDecl.Name.Name
+}
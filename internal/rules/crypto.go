@@ -17,6 +17,8 @@ type InsecureCryptoRule struct {
 	insecureCipherAlgorithms  map[string]bool
 	deprecatedCryptoFunctions map[string]string
 	weakKeyLengths            map[string]int
+	// Устаревшие пакеты golang.org/x/crypto и причина их небезопасности
+	deprecatedXCryptoPackages map[string]string
 }
 
 // NewInsecureCryptoRule создает новое правило для проверки небезопасных криптографических функций
@@ -26,6 +28,12 @@ func NewInsecureCryptoRule() *InsecureCryptoRule {
 			id:          "SEC005",
 			description: "Использование устаревших или небезопасных криптографических функций",
 			severity:    report.SeverityHigh,
+			cwe:         "CWE-327",
+			addedIn:     "0.1.0",
+			remediation: "Используйте SHA-256 и выше для хеширования, AES-GCM или ChaCha20-Poly1305 для шифрования и ключи рекомендуемой длины",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Cryptographic_Storage_Cheat_Sheet.html"},
+			// Стандартные криптографические пакеты, пакеты хеширования и golang.org/x/crypto
+			requiredImports: []string{"crypto", "hash", "golang.org/x/crypto"},
 		},
 		insecureHashAlgorithms: map[string]bool{
 			"MD4":       true,
@@ -60,6 +68,14 @@ func NewInsecureCryptoRule() *InsecureCryptoRule {
 			"DSA":   2048, // Минимум 2048 бит
 			"HMAC":  256,  // Минимум 256 бит
 		},
+		deprecatedXCryptoPackages: map[string]string{
+			"golang.org/x/crypto/md4":       "MD4 криптографически взломан",
+			"golang.org/x/crypto/ripemd160": "RIPEMD-160 устарел и не рекомендуется",
+			"golang.org/x/crypto/cast5":     "CAST5 использует 64-битный блок и устарел",
+			"golang.org/x/crypto/blowfish":  "Blowfish использует 64-битный блок и устарел",
+			"golang.org/x/crypto/tea":       "TEA имеет известные уязвимости связанных ключей",
+			"golang.org/x/crypto/xtea":      "XTEA использует 64-битный блок и устарел",
+		},
 	}
 }
 
@@ -67,25 +83,11 @@ func NewInsecureCryptoRule() *InsecureCryptoRule {
 func (r *InsecureCryptoRule) Check(ctx *Context) []report.Issue {
 	var issues []report.Issue
 
-	// Проверяем импорты на наличие криптографических пакетов
-	hasCryptoImport := false
-	for _, imp := range ctx.File.Imports {
-		if imp.Path != nil {
-			path := strings.Trim(imp.Path.Value, `"`)
-			if strings.HasPrefix(path, "crypto/") ||
-				strings.Contains(path, "crypto") ||
-				strings.Contains(path, "cipher") ||
-				strings.Contains(path, "hash") {
-				hasCryptoImport = true
-				break
-			}
-		}
-	}
+	xCryptoImports := r.xCryptoImports(ctx)
 
-	// Если нет криптографических импортов, нет необходимости проверять дальше
-	if !hasCryptoImport {
-		return issues
-	}
+	// Селекторы вызовов, о которых уже сообщено более точным сообщением из checkCryptoCall.
+	// Вызов обходится раньше своего селектора, поэтому общая проблема для селектора пропускается.
+	reportedCalls := make(map[*ast.SelectorExpr]bool)
 
 	// Проверяем использование криптографических функций
 	ast.Inspect(ctx.File, func(n ast.Node) bool {
@@ -93,6 +95,21 @@ func (r *InsecureCryptoRule) Check(ctx *Context) []report.Issue {
 		case *ast.SelectorExpr:
 			// Проверяем вызовы функций из определенных пакетов
 			if x, ok := node.X.(*ast.Ident); ok {
+				// Проверяем устаревшие примитивы golang.org/x/crypto
+				if path, ok := xCryptoImports[x.Name]; ok {
+					if reason, ok := r.deprecatedXCryptoPackages[path]; ok {
+						issues = append(issues, r.NewIssue(node.Pos(), ctx,
+							"Использование устаревшего примитива "+path+": "+reason))
+					} else if path == "golang.org/x/crypto/ssh" && node.Sel.Name == "InsecureIgnoreHostKey" {
+						issues = append(issues, r.NewIssue(node.Pos(), ctx,
+							"ssh.InsecureIgnoreHostKey отключает проверку ключа хоста, используйте ssh.FixedHostKey или known_hosts"))
+					}
+				}
+
+				if reportedCalls[node] {
+					return true
+				}
+
 				// Проверяем небезопасные пакеты хеширования
 				if x.Name == "md5" || x.Name == "sha1" {
 					issues = append(issues, r.NewIssue(node.Pos(), ctx,
@@ -117,10 +134,20 @@ func (r *InsecureCryptoRule) Check(ctx *Context) []report.Issue {
 			if sel, ok := node.Fun.(*ast.SelectorExpr); ok {
 				if x, ok := sel.X.(*ast.Ident); ok {
 					// Проверяем небезопасные вызовы в определенных пакетах
+					before := len(issues)
 					r.checkCryptoCall(x.Name, sel.Sel.Name, node, ctx, &issues)
+					if len(issues) > before {
+						reportedCalls[sel] = true
+					}
 				}
 			}
 
+		case *ast.FuncDecl:
+			// Проверяем ключи, заполненные через math/rand
+			if node.Body != nil {
+				issues = append(issues, r.checkMathRandKeys(node.Body, ctx)...)
+			}
+
 		case *ast.ValueSpec:
 			// Проверяем объявления переменных для слабых ключей
 			for _, val := range node.Values {
@@ -225,6 +252,135 @@ func (r *InsecureCryptoRule) checkKeyGeneration(pkgName, funcName string, callEx
 	}
 }
 
+// xCryptoImports возвращает локальные имена импортов из golang.org/x/crypto и их пути
+func (r *InsecureCryptoRule) xCryptoImports(ctx *Context) map[string]string {
+	imports := make(map[string]string)
+	for _, imp := range ctx.File.Imports {
+		if imp.Path == nil {
+			continue
+		}
+		path := strings.Trim(imp.Path.Value, `"`)
+		if !strings.HasPrefix(path, "golang.org/x/crypto/") {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// keySinks задает функции, принимающие ключевой материал, и индекс аргумента с ключом
+var keySinks = map[string]int{
+	"aes.NewCipher":          0,
+	"des.NewCipher":          0,
+	"des.NewTripleDESCipher": 0,
+	"rc4.NewCipher":          0,
+	"blowfish.NewCipher":     0,
+	"chacha20poly1305.New":   0,
+	"chacha20poly1305.NewX":  0,
+	"hmac.New":               1,
+	"cipher.NewCBCEncrypter": 1,
+	"cipher.NewCBCDecrypter": 1,
+	"cipher.NewCTR":          1,
+	"cipher.NewCFBEncrypter": 1,
+	"cipher.NewCFBDecrypter": 1,
+	"cipher.NewOFB":          1,
+}
+
+// checkMathRandKeys ищет срезы байтов, заполненные через math/rand и используемые как ключи или IV
+func (r *InsecureCryptoRule) checkMathRandKeys(body *ast.BlockStmt, ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	randName := mathRandImportName(ctx)
+	if randName == "" {
+		return issues
+	}
+
+	// Срезы, заполненные значениями из math/rand
+	weakVars := make(map[string]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			// b[i] = byte(rand.Intn(256))
+			for i, lhs := range node.Lhs {
+				if i >= len(node.Rhs) {
+					continue
+				}
+				if index, ok := lhs.(*ast.IndexExpr); ok {
+					if ident, ok := index.X.(*ast.Ident); ok && containsPackageCall(node.Rhs[i], randName) {
+						weakVars[ident.Name] = true
+					}
+				}
+			}
+
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			x, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			// rand.Read(b) из math/rand
+			if x.Name == randName && sel.Sel.Name == "Read" && len(node.Args) == 1 {
+				if ident, ok := node.Args[0].(*ast.Ident); ok {
+					weakVars[ident.Name] = true
+				}
+			}
+
+			// Использование заполненного среза как ключа
+			if argIndex, ok := keySinks[x.Name+"."+sel.Sel.Name]; ok && argIndex < len(node.Args) {
+				if ident, ok := node.Args[argIndex].(*ast.Ident); ok && weakVars[ident.Name] {
+					issues = append(issues, r.NewIssue(node.Pos(), ctx,
+						"Ключевой материал "+ident.Name+" сгенерирован через math/rand и передается в "+x.Name+"."+sel.Sel.Name+", используйте crypto/rand"))
+				}
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// mathRandImportName возвращает локальное имя импорта math/rand или пустую строку
+func mathRandImportName(ctx *Context) string {
+	for _, imp := range ctx.File.Imports {
+		if imp.Path == nil {
+			continue
+		}
+		path := strings.Trim(imp.Path.Value, `"`)
+		if path == "math/rand" || path == "math/rand/v2" {
+			if imp.Name != nil {
+				return imp.Name.Name
+			}
+			return "rand"
+		}
+	}
+	return ""
+}
+
+// containsPackageCall проверяет, содержит ли выражение вызов функции указанного пакета
+func containsPackageCall(expr ast.Expr, pkgName string) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if callExpr, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+				if x, ok := sel.X.(*ast.Ident); ok && x.Name == pkgName {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
 // isImportedFromCrypto проверяет, что пакет импортирован из crypto/
 func (r *InsecureCryptoRule) isImportedFromCrypto(ctx *Context, pkgName string) bool {
 	for _, imp := range ctx.File.Imports {
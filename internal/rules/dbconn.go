@@ -0,0 +1,223 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// InsecureDBConnectionRule проверяет строки подключения к базам данных на отключенный TLS
+type InsecureDBConnectionRule struct {
+	BaseRule
+	// Пакеты database/sql, драйверов и ORM, при импорте которых выполняется проверка
+	dbPackages []string
+	// Функции, принимающие DSN, и индекс аргумента со строкой подключения
+	dsnFuncs map[string]int
+	// Регулярное выражение для параметров, отключающих TLS
+	insecureParamRegex *regexp.Regexp
+}
+
+// NewInsecureDBConnectionRule создает новое правило для проверки TLS в подключениях к базам данных
+func NewInsecureDBConnectionRule() *InsecureDBConnectionRule {
+	return &InsecureDBConnectionRule{
+		BaseRule: BaseRule{
+			id:          "SEC014",
+			description: "Подключение к базе данных без TLS",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-319",
+			addedIn:     "0.2.0",
+			remediation: "Включите TLS с проверкой сертификата сервера в параметрах подключения к базе данных",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Security_Cheat_Sheet.html"},
+		},
+		dbPackages: []string{
+			"database/sql",
+			"github.com/lib/pq",
+			"github.com/jackc/pgx",
+			"github.com/go-sql-driver/mysql",
+			"github.com/jmoiron/sqlx",
+			"gorm.io/gorm",
+			"gorm.io/driver/",
+		},
+		dsnFuncs: map[string]int{
+			"sql.Open":            1,
+			"sqlx.Open":           1,
+			"sqlx.Connect":        1,
+			"pgx.Connect":         1,
+			"pgx.ParseConfig":     0,
+			"pgxpool.New":         1,
+			"pgxpool.Connect":     1,
+			"pgxpool.ParseConfig": 0,
+			"pq.NewConnector":     0,
+			"postgres.Open":       0,
+			"mysql.Open":          0,
+			"mysql.ParseDSN":      0,
+			"fmt.Sprintf":         0,
+		},
+		insecureParamRegex: regexp.MustCompile(`(?i)\b(sslmode=disable|tls=false|tls=skip-verify)\b`),
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *InsecureDBConnectionRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	if !r.importsDBPackage(ctx) {
+		return issues
+	}
+
+	// Значения переменных и констант файла для разбора DSN, переданного через идентификатор
+	values := collectStringValues(ctx.File)
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			argIndex, ok := r.dsnFuncs[astToString(sel)]
+			if !ok || argIndex >= len(node.Args) {
+				return true
+			}
+
+			var fragments []string
+			if astToString(sel) == "fmt.Sprintf" {
+				// Сборка DSN через fmt.Sprintf: проверяем шаблон и литеральные аргументы
+				for _, arg := range node.Args {
+					fragments = append(fragments, stringFragments(arg, values)...)
+				}
+			} else {
+				fragments = stringFragments(node.Args[argIndex], values)
+			}
+
+			if param := r.insecureParam(fragments); param != "" {
+				issues = append(issues, r.NewIssue(node.Pos(), ctx, r.message(param)))
+			}
+
+		case *ast.CompositeLit:
+			// mysql.Config{TLSConfig: "false"}
+			if astToString(node.Type) != "mysql.Config" {
+				return true
+			}
+			for _, elt := range node.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "TLSConfig" {
+					continue
+				}
+				for _, value := range stringFragments(kv.Value, values) {
+					if value == "false" || value == "skip-verify" {
+						issues = append(issues, r.NewIssue(kv.Pos(), ctx, r.message("tls="+value)))
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// importsDBPackage проверяет, импортирован ли database/sql или известный драйвер
+func (r *InsecureDBConnectionRule) importsDBPackage(ctx *Context) bool {
+	for _, imp := range ctx.File.Imports {
+		if imp.Path == nil {
+			continue
+		}
+		path := strings.Trim(imp.Path.Value, `"`)
+		for _, pkg := range r.dbPackages {
+			if path == pkg || strings.HasPrefix(path, strings.TrimSuffix(pkg, "/")+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// insecureParam возвращает первый параметр, отключающий TLS, среди строковых фрагментов DSN
+func (r *InsecureDBConnectionRule) insecureParam(fragments []string) string {
+	for _, fragment := range fragments {
+		if match := r.insecureParamRegex.FindString(fragment); match != "" {
+			return strings.ToLower(match)
+		}
+	}
+	return ""
+}
+
+// message формирует сообщение для найденного параметра DSN
+func (r *InsecureDBConnectionRule) message(param string) string {
+	if param == "tls=skip-verify" {
+		return "Строка подключения к базе данных содержит " + param + ": сертификат сервера не проверяется, используйте tls=true с проверкой сертификата"
+	}
+	return "Строка подключения к базе данных содержит " + param + ": соединение не шифруется, используйте sslmode=verify-full или tls=true"
+}
+
+// collectStringValues собирает строковые значения переменных и констант файла,
+// инициализированных литералом или конкатенацией литералов
+func collectStringValues(file *ast.File) map[string]ast.Expr {
+	values := make(map[string]ast.Expr)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			for i, value := range node.Values {
+				if i < len(node.Names) {
+					values[node.Names[i].Name] = value
+				}
+			}
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					continue
+				}
+				if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+					values[ident.Name] = rhs
+				}
+			}
+		}
+		return true
+	})
+
+	return values
+}
+
+// stringFragments возвращает строковые литералы, из которых составлено выражение:
+// литерал, конкатенацию или идентификатор со строковым значением
+func stringFragments(expr ast.Expr, values map[string]ast.Expr) []string {
+	var fragments []string
+	visited := make(map[string]bool)
+
+	var walk func(ast.Expr)
+	walk = func(expr ast.Expr) {
+		switch node := expr.(type) {
+		case *ast.BasicLit:
+			if node.Kind != token.STRING {
+				return
+			}
+			if value, err := strconv.Unquote(node.Value); err == nil {
+				fragments = append(fragments, value)
+			}
+		case *ast.BinaryExpr:
+			if node.Op == token.ADD {
+				walk(node.X)
+				walk(node.Y)
+			}
+		case *ast.ParenExpr:
+			walk(node.X)
+		case *ast.Ident:
+			if value, ok := values[node.Name]; ok && !visited[node.Name] {
+				visited[node.Name] = true
+				walk(value)
+			}
+		}
+	}
+	walk(expr)
+
+	return fragments
+}
@@ -0,0 +1,286 @@
+package rules
+
+import (
+	"go/ast"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// deserializationFormat описывает формат десериализации и пакеты, которые его реализуют
+type deserializationFormat struct {
+	// Название формата в сообщении
+	name string
+	// Пути импорта пакетов формата
+	packages map[string]bool
+	// Опасна ли десериализация недоверенных данных в любой целевой тип, а не только в интерфейсный
+	anyTarget bool
+}
+
+// InsecureDeserializationRule проверяет код на десериализацию недоверенных данных в интерфейсные типы
+// и на декодирование недоверенных данных через encoding/gob
+type InsecureDeserializationRule struct {
+	BaseRule
+	// Проверяемые форматы десериализации
+	formats []deserializationFormat
+}
+
+// NewInsecureDeserializationRule создает новое правило для проверки небезопасной десериализации
+func NewInsecureDeserializationRule() *InsecureDeserializationRule {
+	return &InsecureDeserializationRule{
+		BaseRule: BaseRule{
+			id:          "SEC008",
+			description: "Небезопасная десериализация недоверенных данных",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-502",
+			addedIn:     "0.2.0",
+			remediation: "Декодируйте недоверенные данные в конкретные структуры вместо интерфейсных типов и проверяйте результат",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Deserialization_Cheat_Sheet.html"},
+		},
+		formats: []deserializationFormat{
+			{
+				name: "YAML",
+				packages: map[string]bool{
+					"gopkg.in/yaml.v2":         true,
+					"gopkg.in/yaml.v3":         true,
+					"github.com/ghodss/yaml":   true,
+					"sigs.k8s.io/yaml":         true,
+					"github.com/goccy/go-yaml": true,
+				},
+			},
+			{
+				name:     "XML",
+				packages: map[string]bool{"encoding/xml": true},
+			},
+			{
+				// gob восстанавливает типы из потока и расходует память по данным отправителя
+				name:      "gob",
+				packages:  map[string]bool{"encoding/gob": true},
+				anyTarget: true,
+			},
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *InsecureDeserializationRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальные имена импортированных пакетов десериализации
+	formatNames := make(map[string]deserializationFormat)
+	for _, format := range r.formats {
+		if name := importName(ctx, format.packages); name != "" {
+			formatNames[name] = format
+		}
+	}
+	// Правило активируется только при импорте пакета десериализации
+	if len(formatNames) == 0 {
+		return issues
+	}
+
+	userInput := NewTaintTracker(ctx)
+	interfaceVars := collectInterfaceVars(ctx)
+	decoderVars := collectTaintedDecoders(ctx, formatNames, userInput)
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		switch {
+		case sel.Sel.Name == "Unmarshal" && len(callExpr.Args) >= 2:
+			// yaml.Unmarshal(data, &v)
+			pkg, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			format, ok := formatNames[pkg.Name]
+			if !ok {
+				return true
+			}
+			if userInput.IsTainted(callExpr.Args[0]) && (format.anyTarget || isInterfaceTarget(callExpr.Args[1], interfaceVars)) {
+				issues = append(issues, r.newDeserializationIssue(callExpr, ctx, format))
+			}
+
+		case sel.Sel.Name == "Decode" && len(callExpr.Args) == 1:
+			// yaml.NewDecoder(r.Body).Decode(&v) или dec.Decode(&v)
+			var format deserializationFormat
+			var tainted bool
+			switch x := sel.X.(type) {
+			case *ast.CallExpr:
+				format, tainted = taintedDecoder(x, formatNames, userInput)
+			case *ast.Ident:
+				format, tainted = decoderVars[x.Name]
+			}
+			if tainted && (format.anyTarget || isInterfaceTarget(callExpr.Args[0], interfaceVars)) {
+				issues = append(issues, r.newDeserializationIssue(callExpr, ctx, format))
+			}
+		}
+
+		return true
+	})
+
+	return issues
+}
+
+// newDeserializationIssue создает проблему с сообщением для формата десериализации
+func (r *InsecureDeserializationRule) newDeserializationIssue(callExpr *ast.CallExpr, ctx *Context, format deserializationFormat) report.Issue {
+	if format.anyTarget {
+		return r.NewIssue(callExpr.Pos(), ctx,
+			"Декодирование "+format.name+" из пользовательского ввода, используйте формат с явной схемой, например JSON в конкретную структуру")
+	}
+	return r.NewIssue(callExpr.Pos(), ctx,
+		"Десериализация "+format.name+" из пользовательского ввода в интерфейсный тип, используйте конкретную структуру")
+}
+
+// taintedDecoder проверяет, является ли выражение вызовом pkg.NewDecoder с пользовательским вводом,
+// и возвращает формат декодера
+func taintedDecoder(expr ast.Expr, formatNames map[string]deserializationFormat, userInput *TaintTracker) (deserializationFormat, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return deserializationFormat{}, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "NewDecoder" {
+		return deserializationFormat{}, false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return deserializationFormat{}, false
+	}
+	format, ok := formatNames[pkg.Name]
+	if !ok || !userInput.IsTainted(call.Args[0]) {
+		return deserializationFormat{}, false
+	}
+	return format, true
+}
+
+// collectTaintedDecoders находит переменные с декодерами пользовательского ввода: dec := gob.NewDecoder(r.Body)
+func collectTaintedDecoders(ctx *Context, formatNames map[string]deserializationFormat, userInput *TaintTracker) map[string]deserializationFormat {
+	decoders := make(map[string]deserializationFormat)
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					continue
+				}
+				if format, ok := taintedDecoder(rhs, formatNames, userInput); ok {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+						decoders[ident.Name] = format
+					}
+				}
+			}
+
+		case *ast.ValueSpec:
+			for i, val := range node.Values {
+				if i >= len(node.Names) {
+					continue
+				}
+				if format, ok := taintedDecoder(val, formatNames, userInput); ok {
+					decoders[node.Names[i].Name] = format
+				}
+			}
+		}
+		return true
+	})
+
+	return decoders
+}
+
+// importName возвращает локальное имя первого найденного импорта из списка или пустую строку
+func importName(ctx *Context, paths map[string]bool) string {
+	for _, imp := range ctx.File.Imports {
+		if imp.Path == nil {
+			continue
+		}
+
+		path := strings.Trim(imp.Path.Value, `"`)
+		if !paths[path] {
+			continue
+		}
+
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+
+		// Для версионированных путей (gopkg.in/yaml.v3) имя пакета не содержит суффикс версии
+		name := path[strings.LastIndex(path, "/")+1:]
+		if idx := strings.Index(name, "."); idx > 0 {
+			name = name[:idx]
+		}
+		return strings.TrimPrefix(name, "go-")
+	}
+	return ""
+}
+
+// isPackageCall проверяет, является ли селектор вызовом pkg.funcName
+func isPackageCall(sel *ast.SelectorExpr, pkg, funcName string) bool {
+	x, ok := sel.X.(*ast.Ident)
+	return ok && x.Name == pkg && sel.Sel.Name == funcName
+}
+
+// collectInterfaceVars находит переменные, объявленные с интерфейсным типом или картой интерфейсов
+func collectInterfaceVars(ctx *Context) map[string]bool {
+	interfaceVars := make(map[string]bool)
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ValueSpec:
+			// var v interface{}
+			if node.Type != nil && isInterfaceType(node.Type) {
+				for _, name := range node.Names {
+					interfaceVars[name.Name] = true
+				}
+			}
+
+		case *ast.AssignStmt:
+			// v := map[string]interface{}{}
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					continue
+				}
+				if lit, ok := rhs.(*ast.CompositeLit); ok && lit.Type != nil && isInterfaceType(lit.Type) {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+						interfaceVars[ident.Name] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return interfaceVars
+}
+
+// isInterfaceType проверяет, является ли тип пустым интерфейсом или картой/срезом интерфейсов
+func isInterfaceType(expr ast.Expr) bool {
+	switch node := expr.(type) {
+	case *ast.InterfaceType:
+		return node.Methods == nil || len(node.Methods.List) == 0
+	case *ast.Ident:
+		return node.Name == "any"
+	case *ast.MapType:
+		return isInterfaceType(node.Value)
+	case *ast.ArrayType:
+		return isInterfaceType(node.Elt)
+	}
+	return false
+}
+
+// isInterfaceTarget проверяет, указывает ли аргумент десериализации на интерфейсную переменную
+func isInterfaceTarget(expr ast.Expr, interfaceVars map[string]bool) bool {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+
+	ident, ok := expr.(*ast.Ident)
+	return ok && interfaceVars[ident.Name]
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// HardcodedEndpointRule проверяет строковые литералы с адресами внутренней сети: частными IP-адресами
+// (10.0.0.0/8, 172.16.0.0/12, 192.168.0.0/16) и именами хостов в зонах .internal и .local.
+// Такие адреса меняются между окружениями и должны задаваться конфигурацией.
+type HardcodedEndpointRule struct {
+	BaseRule
+	// Регулярное выражение для поиска IPv4-адресов
+	ipRegex *regexp.Regexp
+	// Регулярное выражение для поиска имен хостов во внутренних зонах
+	hostRegex *regexp.Regexp
+}
+
+// NewHardcodedEndpointRule создает новое правило для проверки жестко заданных внутренних адресов
+func NewHardcodedEndpointRule() *HardcodedEndpointRule {
+	return &HardcodedEndpointRule{
+		BaseRule: BaseRule{
+			id:          "SEC029",
+			description: "Жестко заданный адрес внутренней сети",
+			severity:    report.SeverityInfo,
+			cwe:         "CWE-547",
+			addedIn:     "0.2.0",
+			remediation: "Вынесите адреса сервисов в конфигурацию или переменные окружения",
+		},
+		ipRegex:   regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`),
+		hostRegex: regexp.MustCompile(`(?i)\b[a-z0-9](?:[a-z0-9-]*[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)*\.(?:internal|local)\b`),
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *HardcodedEndpointRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Адреса в тестах обычно относятся к тестовому окружению
+	if strings.HasSuffix(ctx.FilePath, "_test.go") {
+		return issues
+	}
+
+	allowlist := ctx.StringSliceSetting("allowlist", nil)
+	tags := make(map[*ast.BasicLit]bool)
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.ImportSpec:
+			// Пути импорта не являются адресами
+			return false
+		case *ast.Field:
+			// Теги полей структур не проверяем
+			if node.Tag != nil {
+				tags[node.Tag] = true
+			}
+		case *ast.BasicLit:
+			if node.Kind != token.STRING || tags[node] {
+				return false
+			}
+			value, err := strconv.Unquote(node.Value)
+			if err != nil {
+				return false
+			}
+			if endpoint := r.findEndpoint(value, allowlist); endpoint != "" {
+				issues = append(issues, r.NewIssueRange(node.Pos(), node.End(), ctx,
+					"Адрес внутренней сети "+endpoint+" задан в коде; вынесите его в конфигурацию или переменные окружения"))
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// findEndpoint возвращает первый частный IP-адрес или имя хоста во внутренней зоне в строке,
+// не входящий в список разрешенных
+func (r *HardcodedEndpointRule) findEndpoint(value string, allowlist []string) string {
+	for _, candidate := range r.ipRegex.FindAllString(value, -1) {
+		ip := net.ParseIP(candidate)
+		if ip != nil && ip.IsPrivate() && !isAllowedEndpoint(candidate, ip, allowlist) {
+			return candidate
+		}
+	}
+	for _, loc := range r.hostRegex.FindAllStringIndex(value, -1) {
+		// Имена файлов вроде ".env.local" и "config.local.yaml" не являются адресами
+		if (loc[0] > 0 && value[loc[0]-1] == '.') || (loc[1] < len(value) && value[loc[1]] == '.') {
+			continue
+		}
+		candidate := value[loc[0]:loc[1]]
+		if !isAllowedEndpoint(strings.ToLower(candidate), nil, allowlist) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// isAllowedEndpoint проверяет адрес по списку разрешенных: точное совпадение, подсеть в нотации CIDR
+// для IP-адресов или суффикс домена, начинающийся с точки (".corp.internal")
+func isAllowedEndpoint(endpoint string, ip net.IP, allowlist []string) bool {
+	for _, allowed := range allowlist {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == endpoint {
+			return true
+		}
+		if ip != nil {
+			if _, network, err := net.ParseCIDR(allowed); err == nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if strings.HasPrefix(allowed, ".") && strings.HasSuffix(endpoint, allowed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"go/ast"
+
+	"go-audit/pkg/report"
+)
+
+// SensitiveErrorRule проверяет создание ошибок, в текст которых попадают секретные значения:
+// fmt.Errorf("неверный токен %s", token). Текст ошибки уходит в логи и ответы клиентам вместе с секретом.
+type SensitiveErrorRule struct {
+	BaseRule
+	// Чувствительные имена из правила поиска секретов
+	secrets *HardcodedSecretsRule
+	// Функции создания ошибок по пакетам
+	errorFuncs map[string]map[string]bool
+}
+
+// NewSensitiveErrorRule создает новое правило для проверки секретных значений в тексте ошибок
+func NewSensitiveErrorRule() *SensitiveErrorRule {
+	return &SensitiveErrorRule{
+		BaseRule: BaseRule{
+			id:          "SEC028",
+			description: "Секретное значение в тексте ошибки",
+			severity:    report.SeverityLow,
+			cwe:         "CWE-209",
+			addedIn:     "0.2.0",
+			remediation: "Не включайте пароли, токены и ключи в текст ошибок, указывайте только идентификатор объекта или причину",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Error_Handling_Cheat_Sheet.html"},
+		},
+		secrets: NewHardcodedSecretsRule(),
+		errorFuncs: map[string]map[string]bool{
+			"fmt":    {"Errorf": true},
+			"errors": {"New": true},
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *SensitiveErrorRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальные имена пакетов с учетом псевдонимов импорта
+	packages := make(map[string]string)
+	for pkg := range r.errorFuncs {
+		if name := importName(ctx, map[string]bool{pkg: true}); name != "" {
+			packages[name] = pkg
+		}
+	}
+	if len(packages) == 0 {
+		return issues
+	}
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkgIdent, ok := sel.X.(*ast.Ident)
+		if !ok || !r.errorFuncs[packages[pkgIdent.Name]][sel.Sel.Name] {
+			return true
+		}
+
+		for _, arg := range call.Args {
+			if name := r.sensitiveValue(arg); name != "" {
+				issues = append(issues, r.NewIssueRange(call.Pos(), call.End(), ctx,
+					"Значение "+name+" попадает в текст ошибки "+astToString(sel)+
+						" и может раскрыться в логах или ответах клиентам; не включайте секреты в текст ошибок"))
+				break
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// sensitiveValue возвращает имя переменной или поля с чувствительным именем, значение которого
+// входит в выражение. Длина значения секрет не раскрывает, поэтому аргументы len и cap пропускаются.
+func (r *SensitiveErrorRule) sensitiveValue(expr ast.Expr) string {
+	var name string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if name != "" {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if ident, ok := node.Fun.(*ast.Ident); ok && (ident.Name == "len" || ident.Name == "cap") {
+				return false
+			}
+		case *ast.SelectorExpr:
+			// Поле структуры: cfg.Password
+			if r.secrets.hasSensitiveWord(node.Sel.Name) {
+				name = astToString(node)
+			}
+			return false
+		case *ast.Ident:
+			if r.secrets.hasSensitiveWord(node.Name) {
+				name = node.Name
+			}
+		}
+		return true
+	})
+	return name
+}
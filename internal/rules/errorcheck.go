@@ -13,6 +13,8 @@ type MissingErrorCheckRule struct {
 	BaseRule
 	// Карта функций, которые возвращают ошибки и требуют проверки
 	criticalFunctions map[string]bool
+	// Функции, ошибка которых теряется при вызове в defer, помимо критических
+	deferredFunctions map[string]bool
 }
 
 // NewMissingErrorCheckRule создает новое правило для проверки отсутствия обработки ошибок
@@ -22,6 +24,9 @@ func NewMissingErrorCheckRule() *MissingErrorCheckRule {
 			id:          "SEC004",
 			description: "Отсутствует проверка ошибки после критической операции",
 			severity:    report.SeverityMedium,
+			cwe:         "CWE-252",
+			addedIn:     "0.1.0",
+			remediation: "Проверяйте и обрабатывайте ошибки критических операций, включая отложенные вызовы Close, Sync и Flush",
 		},
 		criticalFunctions: map[string]bool{
 			"Write":             true,
@@ -54,6 +59,10 @@ func NewMissingErrorCheckRule() *MissingErrorCheckRule {
 			"Run":               true,
 			"Copy":              true,
 		},
+		deferredFunctions: map[string]bool{
+			"Sync":  true,
+			"Flush": true,
+		},
 	}
 }
 
@@ -61,6 +70,8 @@ func NewMissingErrorCheckRule() *MissingErrorCheckRule {
 func (r *MissingErrorCheckRule) Check(ctx *Context) []report.Issue {
 	var issues []report.Issue
 
+	isLogging := loggingCallMatcher(ctx)
+
 	// Создаем карту для отслеживания проверенных ошибок
 	checkedErrors := make(map[token.Pos]bool)
 
@@ -69,18 +80,28 @@ func (r *MissingErrorCheckRule) Check(ctx *Context) []report.Issue {
 		// Проверяем выражения сравнения (if err != nil, if err == nil)
 		if binExpr, ok := n.(*ast.BinaryExpr); ok {
 			if isErrorCheck(binExpr) {
-				// Отмечаем, что ошибка проверена
-				if ident, ok := binExpr.X.(*ast.Ident); ok {
-					checkedErrors[ident.Obj.Pos()] = true
+				// Отмечаем, что ошибка проверена; nil может стоять с любой стороны сравнения
+				for _, side := range []ast.Expr{binExpr.X, binExpr.Y} {
+					if ident, ok := side.(*ast.Ident); ok && ident.Name != "nil" && ident.Obj != nil {
+						checkedErrors[ident.Obj.Pos()] = true
+					}
 				}
 			}
 		}
 
+		// Ошибка, объявленная в инициализации if и использованная в условии, проверена:
+		// if _, err := f(); err != nil или if err := f(); errors.Is(err, fs.ErrNotExist)
+		if ifStmt, ok := n.(*ast.IfStmt); ok {
+			if assign, ok := ifStmt.Init.(*ast.AssignStmt); ok {
+				markUsedInCondition(assign, ifStmt.Cond, checkedErrors)
+			}
+		}
+
 		// Проверяем на использование ошибок в логах/печати
 		if callExpr, ok := n.(*ast.CallExpr); ok {
 			if sel, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 				// Проверяем функции логирования (log.Error, fmt.Println и т.д.)
-				if isLoggingFunction(sel) {
+				if isLogging(sel) {
 					for _, arg := range callExpr.Args {
 						if ident, ok := arg.(*ast.Ident); ok && ident.Obj != nil {
 							checkedErrors[ident.Obj.Pos()] = true
@@ -102,6 +123,9 @@ func (r *MissingErrorCheckRule) Check(ctx *Context) []report.Issue {
 		return true
 	})
 
+	// Файлы, открытые только для чтения: ошибка их закрытия не приводит к потере данных
+	readOnly := collectReadOnlyFiles(ctx.File)
+
 	// Затем ищем критические функции с непроверенными ошибками
 	ast.Inspect(ctx.File, func(n ast.Node) bool {
 		switch node := n.(type) {
@@ -156,6 +180,19 @@ func (r *MissingErrorCheckRule) Check(ctx *Context) []report.Issue {
 				}
 			}
 
+		case *ast.DeferStmt:
+			// defer f.Close() отбрасывает ошибку: для записываемых файлов она может означать потерю данных
+			if sel, ok := node.Call.Fun.(*ast.SelectorExpr); ok {
+				name := sel.Sel.Name
+				if (r.criticalFunctions[name] || r.deferredFunctions[name]) && !readOnly[receiverName(sel.X)] && !isBodySelector(sel.X) {
+					issue := r.NewIssue(node.Pos(), ctx,
+						"Ошибка "+name+" в defer игнорируется, сохраните ее в именованный результат: "+
+							"defer func() { if cerr := "+astToString(sel.X)+"."+name+"(); cerr != nil && err == nil { err = cerr } }()")
+					issue.Severity = report.SeverityLow
+					issues = append(issues, issue)
+				}
+			}
+
 		case *ast.ExprStmt:
 			// Проверяем выражения-вызовы без присваивания результата
 			if callExpr, ok := node.X.(*ast.CallExpr); ok {
@@ -175,6 +212,55 @@ func (r *MissingErrorCheckRule) Check(ctx *Context) []report.Issue {
 	return issues
 }
 
+// markUsedInCondition отмечает проверенными переменные, объявленные в инициализации if и используемые в его условии
+func markUsedInCondition(init *ast.AssignStmt, cond ast.Expr, checkedErrors map[token.Pos]bool) {
+	declared := make(map[*ast.Object]bool)
+	for _, lhs := range init.Lhs {
+		if ident, ok := lhs.(*ast.Ident); ok && ident.Obj != nil {
+			declared[ident.Obj] = true
+		}
+	}
+
+	ast.Inspect(cond, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Obj != nil && declared[ident.Obj] {
+			checkedErrors[ident.Obj.Pos()] = true
+		}
+		return true
+	})
+}
+
+// collectReadOnlyFiles находит переменные, которым присвоен файл, открытый только для чтения: f, err := os.Open(path)
+func collectReadOnlyFiles(file *ast.File) map[string]bool {
+	readOnly := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+			return true
+		}
+		if call, ok := assign.Rhs[0].(*ast.CallExpr); ok && astToString(call.Fun) == "os.Open" {
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok {
+				readOnly[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return readOnly
+}
+
+// receiverName возвращает имя переменной-получателя вызова или пустую строку
+func receiverName(expr ast.Expr) string {
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// isBodySelector проверяет, является ли выражение телом запроса или ответа: resp.Body
+func isBodySelector(expr ast.Expr) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Body"
+}
+
 // isErrorCheck проверяет, является ли бинарное выражение проверкой ошибки
 func isErrorCheck(expr *ast.BinaryExpr) bool {
 	// Проверяем на err != nil или err == nil
@@ -198,38 +284,3 @@ func isErrorCheck(expr *ast.BinaryExpr) bool {
 
 	return false
 }
-
-// isLoggingFunction проверяет, является ли вызов функцией логирования
-func isLoggingFunction(sel *ast.SelectorExpr) bool {
-	if x, ok := sel.X.(*ast.Ident); ok {
-		// Проверяем распространенные пакеты и функции логирования
-		pkgName := x.Name
-		funcName := sel.Sel.Name
-
-		// log пакет
-		if pkgName == "log" && (funcName == "Fatal" || funcName == "Fatalf" ||
-			funcName == "Print" || funcName == "Printf" ||
-			funcName == "Panic" || funcName == "Panicf") {
-			return true
-		}
-
-		// fmt пакет для печати
-		if pkgName == "fmt" && (funcName == "Print" || funcName == "Printf" ||
-			funcName == "Println" || funcName == "Sprint" ||
-			funcName == "Sprintf" || funcName == "Sprintln") {
-			return true
-		}
-
-		// популярные библиотеки логирования
-		if (pkgName == "logger" || pkgName == "logging" || pkgName == "logrus" ||
-			pkgName == "zap" || pkgName == "zerolog") &&
-			(funcName == "Error" || funcName == "Errorf" ||
-				funcName == "Warn" || funcName == "Warnf" ||
-				funcName == "Info" || funcName == "Infof" ||
-				funcName == "Debug" || funcName == "Debugf") {
-			return true
-		}
-	}
-
-	return false
-}
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+
+	"go-audit/pkg/report"
+)
+
+// groupOtherWriteBits биты записи для группы и остальных пользователей
+const groupOtherWriteBits = 0o022
+
+// InsecureFilePermsRule проверяет права доступа создаваемых файлов и директорий
+type InsecureFilePermsRule struct {
+	BaseRule
+	// Функции, принимающие права доступа, и индекс аргумента с правами
+	permFuncs map[string]int
+}
+
+// NewInsecureFilePermsRule создает новое правило для проверки прав доступа к файлам
+func NewInsecureFilePermsRule() *InsecureFilePermsRule {
+	return &InsecureFilePermsRule{
+		BaseRule: BaseRule{
+			id:          "SEC015",
+			description: "Файл или директория доступны для записи группе или всем пользователям",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-732",
+			addedIn:     "0.2.0",
+			remediation: "Задавайте права 0600 для файлов и 0700 для директорий, если доступ других пользователей не требуется",
+		},
+		permFuncs: map[string]int{
+			"os.OpenFile":      2,
+			"os.Chmod":         1,
+			"os.WriteFile":     2,
+			"os.Mkdir":         1,
+			"os.MkdirAll":      1,
+			"ioutil.WriteFile": 2,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *InsecureFilePermsRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		funcName := astToString(sel)
+		argIndex, ok := r.permFuncs[funcName]
+		if !ok || argIndex >= len(callExpr.Args) {
+			return true
+		}
+
+		mode, ok := fileModeValue(ctx, callExpr.Args[argIndex])
+		if !ok || mode&groupOtherWriteBits == 0 {
+			return true
+		}
+
+		issues = append(issues, r.NewIssue(callExpr.Args[argIndex].Pos(), ctx,
+			fmt.Sprintf("Права доступа %#o в %s разрешают запись группе или всем пользователям, используйте 0600/0644 для файлов и 0700/0755 для директорий", mode, funcName)))
+		return true
+	})
+
+	return issues
+}
+
+// fileModeValue вычисляет значение прав доступа: литерал 0666 или 0o666,
+// преобразование os.FileMode(0666) или константу, известную проверке типов
+func fileModeValue(ctx *Context, expr ast.Expr) (int64, bool) {
+	if ctx.TypesInfo != nil {
+		if tv, ok := ctx.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.Int {
+			return constant.Int64Val(tv.Value)
+		}
+	}
+
+	// Преобразование типа: os.FileMode(0666), fs.FileMode(0666)
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "FileMode" {
+			return fileModeValue(ctx, call.Args[0])
+		}
+		return 0, false
+	}
+
+	return intConstValue(expr)
+}
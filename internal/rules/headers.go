@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"go/ast"
+	"strconv"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// defaultRequiredHeaders заголовки безопасности, которые обработчик должен устанавливать по умолчанию
+var defaultRequiredHeaders = []string{
+	"Content-Security-Policy",
+	"X-Content-Type-Options",
+	"Strict-Transport-Security",
+}
+
+// MissingSecurityHeadersRule проверяет HTTP-обработчики, которые пишут ответ,
+// не устанавливая заголовки безопасности
+type MissingSecurityHeadersRule struct {
+	BaseRule
+}
+
+// NewMissingSecurityHeadersRule создает новое правило для проверки заголовков безопасности в ответах
+func NewMissingSecurityHeadersRule() *MissingSecurityHeadersRule {
+	return &MissingSecurityHeadersRule{
+		BaseRule: BaseRule{
+			id:          "SEC021",
+			description: "HTTP-ответ без заголовков безопасности",
+			severity:    report.SeverityLow,
+			cwe:         "CWE-693",
+			addedIn:     "0.2.0",
+			remediation: "Устанавливайте заголовки безопасности в ответах, например в общем middleware",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/HTTP_Headers_Cheat_Sheet.html"},
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *MissingSecurityHeadersRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальное имя net/http с учетом псевдонима импорта
+	httpName := importName(ctx, map[string]bool{"net/http": true})
+	if httpName == "" {
+		return issues
+	}
+
+	required := ctx.StringSliceSetting("requiredHeaders", defaultRequiredHeaders)
+	if len(required) == 0 {
+		return issues
+	}
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		writer := handlerWriterName(funcDecl.Type, httpName)
+		if writer == "" {
+			continue
+		}
+
+		writes, headers := collectResponseWrites(funcDecl.Body, writer)
+		if !writes {
+			continue
+		}
+
+		var missing []string
+		for _, header := range required {
+			if !headers[strings.ToLower(header)] {
+				missing = append(missing, header)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		issues = append(issues, r.NewIssue(funcDecl.Pos(), ctx,
+			"Обработчик "+funcDisplayName(funcDecl)+" пишет ответ без заголовков безопасности: "+strings.Join(missing, ", ")+
+				", установите их в обработчике или в общем middleware"))
+	}
+
+	return issues
+}
+
+// handlerWriterName возвращает имя параметра http.ResponseWriter, если функция является HTTP-обработчиком
+// с параметрами http.ResponseWriter и *http.Request
+func handlerWriterName(funcType *ast.FuncType, httpName string) string {
+	if funcType.Params == nil {
+		return ""
+	}
+
+	var writer string
+	var hasRequest bool
+	for _, field := range funcType.Params.List {
+		if star, ok := field.Type.(*ast.StarExpr); ok && astToString(star.X) == httpName+".Request" {
+			hasRequest = true
+		}
+		if astToString(field.Type) == httpName+".ResponseWriter" && len(field.Names) > 0 {
+			writer = field.Names[0].Name
+		}
+	}
+
+	if !hasRequest || writer == "_" {
+		return ""
+	}
+	return writer
+}
+
+// collectResponseWrites определяет, пишет ли обработчик тело или статус ответа,
+// и собирает заголовки в нижнем регистре, установленные через w.Header().Set или w.Header().Add
+func collectResponseWrites(body *ast.BlockStmt, writer string) (bool, map[string]bool) {
+	writes := false
+	headers := make(map[string]bool)
+	// Переменные с заголовками ответа: h := w.Header()
+	headerVars := make(map[string]bool)
+	isHeaderCall := func(expr ast.Expr) bool {
+		call, ok := expr.(*ast.CallExpr)
+		return ok && len(call.Args) == 0 && astToString(call) == writer+".Header"
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					continue
+				}
+				if ident, ok := node.Lhs[i].(*ast.Ident); ok && isHeaderCall(rhs) {
+					headerVars[ident.Name] = true
+				}
+			}
+
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			switch {
+			case astToString(sel.X) == writer && (sel.Sel.Name == "Write" || sel.Sel.Name == "WriteHeader"):
+				// w.Write(data), w.WriteHeader(status)
+				writes = true
+
+			case (strings.HasPrefix(astToString(sel), "fmt.Fprint") || astToString(sel) == "io.WriteString") &&
+				len(node.Args) > 0 && astToString(node.Args[0]) == writer:
+				// fmt.Fprintf(w, ...), io.WriteString(w, ...)
+				writes = true
+
+			case (sel.Sel.Name == "Set" || sel.Sel.Name == "Add") && len(node.Args) > 0 &&
+				(isHeaderCall(sel.X) || headerVars[astToString(sel.X)]):
+				// w.Header().Set("X-Content-Type-Options", "nosniff")
+				if lit, ok := node.Args[0].(*ast.BasicLit); ok {
+					if key, err := strconv.Unquote(lit.Value); err == nil {
+						headers[strings.ToLower(key)] = true
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	return writes, headers
+}
@@ -12,6 +12,10 @@ import (
 // InsecureHTTPRule проверяет код на наличие небезопасных HTTP-настроек
 type InsecureHTTPRule struct {
 	BaseRule
+	// Настройки GODEBUG, ослабляющие проверку сертификатов
+	insecureGodebugSettings map[string]string
+	// Фрагменты имен слабых наборов шифров TLS
+	weakCipherMarkers []string
 }
 
 // NewInsecureHTTPRule создает новое правило для проверки небезопасных HTTP-настроек
@@ -21,7 +25,17 @@ func NewInsecureHTTPRule() *InsecureHTTPRule {
 			id:          "SEC003",
 			description: "Обнаружены небезопасные настройки HTTP-сервера",
 			severity:    report.SeverityHigh,
+			cwe:         "CWE-319",
+			addedIn:     "0.1.0",
+			remediation: "Используйте TLS 1.2 или выше, не отключайте проверку сертификатов и задавайте таймауты HTTP-сервера",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Security_Cheat_Sheet.html"},
 		},
+		insecureGodebugSettings: map[string]string{
+			"x509sha1=1":           "разрешает сертификаты с подписью SHA-1",
+			"x509ignorecn=0":       "разрешает проверку имени хоста по полю Common Name",
+			"x509negativeserial=1": "разрешает сертификаты с отрицательным серийным номером",
+		},
+		weakCipherMarkers: []string{"_RC4_", "_3DES_", "_CBC_SHA"},
 	}
 }
 
@@ -29,6 +43,10 @@ func NewInsecureHTTPRule() *InsecureHTTPRule {
 func (r *InsecureHTTPRule) Check(ctx *Context) []report.Issue {
 	var issues []report.Issue
 
+	// Локальные имена пакетов HTTP/2 без TLS (h2c) и настройки HTTP/2 сервера
+	h2cName := importName(ctx, map[string]bool{"golang.org/x/net/http2/h2c": true})
+	http2Name := importName(ctx, map[string]bool{"golang.org/x/net/http2": true})
+
 	ast.Inspect(ctx.File, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.CompositeLit:
@@ -45,20 +63,53 @@ func (r *InsecureHTTPRule) Check(ctx *Context) []report.Issue {
 			// Проверяем вызовы функций
 			if callExpr, ok := node.Fun.(*ast.SelectorExpr); ok {
 				if r.isInsecureHTTPFunction(callExpr) {
-					issues = append(issues, r.NewIssue(node.Pos(), ctx,
+					issues = append(issues, r.NewIssueRange(node.Pos(), node.End(), ctx,
 						"Использование небезопасной HTTP-функции "+callExpr.Sel.Name))
 				}
 			}
 
+			// Проверяем обслуживание HTTP/2 без TLS через h2c.NewHandler
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && h2cName != "" && isPackageCall(sel, h2cName, "NewHandler") {
+				issue := r.NewIssueRange(node.Pos(), node.End(), ctx,
+					"h2c.NewHandler обслуживает HTTP/2 без TLS (h2c): трафик передается в открытом виде, используйте HTTP/2 поверх TLS")
+				issue.Severity = report.SeverityMedium
+				issues = append(issues, issue)
+			}
+
+			// Проверяем ослабление проверки сертификатов через os.Setenv("GODEBUG", ...)
+			if setting := r.insecureGodebugCall(node); setting != "" {
+				issues = append(issues, r.NewIssueRange(node.Pos(), node.End(), ctx, r.godebugMessage(setting)))
+			}
+
 			// Проверяем на использование HTTP вместо HTTPS для URL
 			if r.isHTTPURLInCode(node) {
-				issues = append(issues, r.NewIssue(node.Pos(), ctx,
+				issues = append(issues, r.NewIssueRange(node.Pos(), node.End(), ctx,
 					"Использование HTTP вместо HTTPS, что не рекомендуется с точки зрения безопасности"))
 			}
+
+		case *ast.FuncDecl:
+			// Проверяем передачу учетных данных по HTTP в пределах функции
+			if node.Body != nil {
+				issues = append(issues, r.checkBasicAuthOverHTTP(node.Body, ctx)...)
+				if http2Name != "" {
+					issues = append(issues, r.checkCleartextHTTP2(node.Body, ctx, http2Name)...)
+				}
+			}
 		}
 		return true
 	})
 
+	// Директивы //go:debug x509sha1=1 в файле
+	for _, group := range ctx.File.Comments {
+		for _, comment := range group.List {
+			if value, ok := strings.CutPrefix(comment.Text, "//go:debug "); ok {
+				if setting := r.insecureGodebugSetting(value); setting != "" {
+					issues = append(issues, r.NewIssue(comment.Pos(), ctx, r.godebugMessage(setting)))
+				}
+			}
+		}
+	}
+
 	return issues
 }
 
@@ -113,17 +164,121 @@ func (r *InsecureHTTPRule) isHTTPURLInCode(callExpr *ast.CallExpr) bool {
 		if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
 			// Проверяем наличие HTTP URL, но не HTTPS
 			value := strings.Trim(lit.Value, `"'`)
-			if strings.HasPrefix(value, "http://") {
-				// Исключаем localhost и локальные адреса
-				if !strings.Contains(value, "localhost") && !regexp.MustCompile(`http://127\.0\.0\.1`).MatchString(value) && !regexp.MustCompile(`http://0\.0\.0\.0`).MatchString(value) {
-					return true
+			if isInsecureHTTPURL(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isInsecureHTTPURL проверяет, является ли значение HTTP URL, не указывающим на локальный адрес
+func isInsecureHTTPURL(value string) bool {
+	if !strings.HasPrefix(value, "http://") {
+		return false
+	}
+
+	// Исключаем localhost и локальные адреса
+	return !strings.Contains(value, "localhost") && !regexp.MustCompile(`http://127\.0\.0\.1`).MatchString(value) && !regexp.MustCompile(`http://0\.0\.0\.0`).MatchString(value)
+}
+
+// checkBasicAuthOverHTTP ищет передачу учетных данных Basic Auth в запросах по HTTP без TLS
+func (r *InsecureHTTPRule) checkBasicAuthOverHTTP(body *ast.BlockStmt, ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Переменные, содержащие HTTP URL, и запросы, построенные по таким URL
+	urlVars := make(map[string]bool)
+	insecureRequests := make(map[string]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					continue
+				}
+				ident, ok := node.Lhs[i].(*ast.Ident)
+				if !ok {
+					continue
+				}
+
+				if r.isInsecureURLExpr(rhs, urlVars) {
+					urlVars[ident.Name] = true
+				}
+
+				// req, err := http.NewRequest(method, "http://...", body)
+				if callExpr, ok := rhs.(*ast.CallExpr); ok {
+					if urlArg := newRequestURLArg(callExpr); urlArg != nil && r.isInsecureURLExpr(urlArg, urlVars) {
+						insecureRequests[ident.Name] = true
+					}
+				}
+			}
+
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			// req.SetBasicAuth(user, pass)
+			if sel.Sel.Name == "SetBasicAuth" {
+				if ident, ok := sel.X.(*ast.Ident); ok && insecureRequests[ident.Name] {
+					issues = append(issues, r.NewIssueRange(node.Pos(), node.End(), ctx,
+						"Учетные данные Basic Auth передаются по HTTP без TLS, используйте HTTPS"))
+				}
+			}
+
+			// req.Header.Set("Authorization", ...)
+			if (sel.Sel.Name == "Set" || sel.Sel.Name == "Add") && len(node.Args) > 0 {
+				if header, ok := sel.X.(*ast.SelectorExpr); ok && header.Sel.Name == "Header" {
+					if ident, ok := header.X.(*ast.Ident); ok && insecureRequests[ident.Name] {
+						if lit, ok := node.Args[0].(*ast.BasicLit); ok && strings.EqualFold(strings.Trim(lit.Value, `"`), "Authorization") {
+							issues = append(issues, r.NewIssueRange(node.Pos(), node.End(), ctx,
+								"Заголовок Authorization передается по HTTP без TLS, используйте HTTPS"))
+						}
+					}
 				}
 			}
 		}
+		return true
+	})
+
+	return issues
+}
+
+// isInsecureURLExpr проверяет, является ли выражение HTTP URL без TLS
+func (r *InsecureHTTPRule) isInsecureURLExpr(expr ast.Expr, urlVars map[string]bool) bool {
+	switch node := expr.(type) {
+	case *ast.BasicLit:
+		return node.Kind == token.STRING && isInsecureHTTPURL(strings.Trim(node.Value, "\"`"))
+	case *ast.Ident:
+		return urlVars[node.Name]
+	case *ast.BinaryExpr:
+		// "http://host/" + path
+		return node.Op == token.ADD && r.isInsecureURLExpr(node.X, urlVars)
 	}
 	return false
 }
 
+// newRequestURLArg возвращает аргумент URL вызова http.NewRequest или http.NewRequestWithContext
+func newRequestURLArg(callExpr *ast.CallExpr) ast.Expr {
+	sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if x, ok := sel.X.(*ast.Ident); !ok || x.Name != "http" {
+		return nil
+	}
+
+	switch {
+	case sel.Sel.Name == "NewRequest" && len(callExpr.Args) >= 2:
+		return callExpr.Args[1]
+	case sel.Sel.Name == "NewRequestWithContext" && len(callExpr.Args) >= 3:
+		return callExpr.Args[2]
+	}
+	return nil
+}
+
 // checkTLSConfig проверяет небезопасные настройки в tls.Config
 func (r *InsecureHTTPRule) checkTLSConfig(lit *ast.CompositeLit, ctx *Context) []report.Issue {
 	var issues []report.Issue
@@ -135,19 +290,43 @@ func (r *InsecureHTTPRule) checkTLSConfig(lit *ast.CompositeLit, ctx *Context) [
 				case "InsecureSkipVerify":
 					// Проверяем InsecureSkipVerify = true
 					if val, ok := kv.Value.(*ast.Ident); ok && val.Name == "true" {
-						issues = append(issues, r.NewIssue(kv.Pos(), ctx,
-							"InsecureSkipVerify=true отключает проверку сертификатов TLS, что опасно"))
+						issue := r.NewIssueRange(kv.Pos(), kv.End(), ctx,
+							"InsecureSkipVerify=true отключает проверку сертификатов TLS, что опасно")
+						issue.SuggestedFix = NewSuggestedFix(val.Pos(), val.End(), ctx, "false",
+							"Включить проверку сертификатов TLS")
+						issue.SuggestedFix.SafeToApply = true
+						issues = append(issues, issue)
 					}
 				case "MinVersion":
 					// Проверяем на низкие версии TLS
 					if sel, ok := kv.Value.(*ast.SelectorExpr); ok {
 						if ident, ok := sel.X.(*ast.Ident); ok && ident.Name == "tls" {
 							if sel.Sel.Name == "VersionSSL30" || sel.Sel.Name == "VersionTLS10" || sel.Sel.Name == "VersionTLS11" {
-								issues = append(issues, r.NewIssue(kv.Pos(), ctx,
+								issues = append(issues, r.NewIssueRange(kv.Pos(), kv.End(), ctx,
 									"Использование устаревшей и небезопасной версии TLS: "+sel.Sel.Name))
 							}
 						}
 					}
+				case "CipherSuites":
+					// Проверяем явно заданные слабые наборы шифров
+					if list, ok := kv.Value.(*ast.CompositeLit); ok {
+						for _, suite := range list.Elts {
+							sel, ok := suite.(*ast.SelectorExpr)
+							if !ok || !r.isWeakCipherSuite(sel) {
+								continue
+							}
+							issue := r.NewIssueRange(sel.Pos(), sel.End(), ctx,
+								"Слабый набор шифров TLS в CipherSuites: "+sel.Sel.Name+"; используйте наборы с AEAD (GCM или ChaCha20-Poly1305)")
+							issue.Severity = report.SeverityMedium
+							issues = append(issues, issue)
+						}
+					}
+				case "VerifyPeerCertificate", "VerifyConnection":
+					// Проверяем функцию проверки, которая всегда возвращает nil
+					if r.isNoopVerifier(kv.Value, ctx) {
+						issues = append(issues, r.NewIssueRange(kv.Pos(), kv.End(), ctx,
+							key.Name+" всегда возвращает nil: собственная проверка сертификата ничего не проверяет"))
+					}
 				}
 			}
 		}
@@ -156,10 +335,101 @@ func (r *InsecureHTTPRule) checkTLSConfig(lit *ast.CompositeLit, ctx *Context) [
 	return issues
 }
 
+// isWeakCipherSuite проверяет, является ли выражение слабым набором шифров пакета tls:
+// RC4, 3DES или CBC с HMAC-SHA, уязвимые к атакам на шифр и режим шифрования
+func (r *InsecureHTTPRule) isWeakCipherSuite(sel *ast.SelectorExpr) bool {
+	if ident, ok := sel.X.(*ast.Ident); !ok || ident.Name != "tls" {
+		return false
+	}
+	for _, marker := range r.weakCipherMarkers {
+		if strings.Contains(sel.Sel.Name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCleartextHTTP2 проверяет вызовы http2.ConfigureServer для серверов без TLS: у сервера нет TLSConfig,
+// и в функции он не запускается через ListenAndServeTLS или ServeTLS
+func (r *InsecureHTTPRule) checkCleartextHTTP2(body *ast.BlockStmt, ctx *Context, http2Name string) []report.Issue {
+	var issues []report.Issue
+
+	// Серверы, для которых в функции есть признаки TLS, и литералы серверов по имени переменной
+	tlsServers := make(map[string]bool)
+	servers := make(map[string]*ast.CompositeLit)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && (sel.Sel.Name == "ListenAndServeTLS" || sel.Sel.Name == "ServeTLS") {
+				tlsServers[astToString(sel.X)] = true
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && sel.Sel.Name == "TLSConfig" {
+					tlsServers[astToString(sel.X)] = true
+				}
+				if i < len(node.Rhs) {
+					if lit, ok := unwrapAddr(node.Rhs[i]).(*ast.CompositeLit); ok && r.isHTTPServerLiteral(lit) {
+						servers[astToString(lhs)] = lit
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isPackageCall(sel, http2Name, "ConfigureServer") {
+			return true
+		}
+
+		server := unwrapAddr(call.Args[0])
+		lit, ok := server.(*ast.CompositeLit)
+		if !ok {
+			name := astToString(server)
+			if tlsServers[name] {
+				return true
+			}
+			if lit, ok = servers[name]; !ok {
+				// Сервер создан вне функции: настройки TLS неизвестны
+				return true
+			}
+		}
+		if compositeLitValue(lit, "TLSConfig") != nil {
+			return true
+		}
+
+		issue := r.NewIssueRange(call.Pos(), call.End(), ctx,
+			"http2.ConfigureServer для сервера без TLS: HTTP/2 будет обслуживаться в открытом виде, "+
+				"задайте TLSConfig или запускайте сервер через ListenAndServeTLS")
+		issue.Severity = report.SeverityMedium
+		issues = append(issues, issue)
+		return true
+	})
+
+	return issues
+}
+
 // checkHTTPTransport проверяет небезопасные настройки в http.Transport
 func (r *InsecureHTTPRule) checkHTTPTransport(lit *ast.CompositeLit, ctx *Context) []report.Issue {
 	var issues []report.Issue
 
+	// RootCAs: nil вместе с собственным DialTLS: корневые сертификаты не заданы явно,
+	// а установка TLS-соединения выполняется в обход настроек транспорта
+	if dialer := compositeLitKey(lit, "DialTLS", "DialTLSContext"); dialer != "" {
+		if tlsConfig, ok := unwrapAddr(compositeLitValue(lit, "TLSClientConfig")).(*ast.CompositeLit); ok {
+			if rootCAs, ok := compositeLitValue(tlsConfig, "RootCAs").(*ast.Ident); ok && rootCAs.Name == "nil" {
+				issues = append(issues, r.NewIssue(rootCAs.Pos(), ctx,
+					"RootCAs: nil при собственной функции "+dialer+": убедитесь, что "+dialer+" проверяет цепочку сертификатов сервера"))
+			}
+		}
+	}
+
 	for _, elt := range lit.Elts {
 		if kv, ok := elt.(*ast.KeyValueExpr); ok {
 			if key, ok := kv.Key.(*ast.Ident); ok {
@@ -171,7 +441,7 @@ func (r *InsecureHTTPRule) checkHTTPTransport(lit *ast.CompositeLit, ctx *Contex
 				} else if key.Name == "DisableKeepAlives" || key.Name == "DisableCompression" {
 					// Проверяем на отключение важных функций безопасности
 					if ident, ok := kv.Value.(*ast.Ident); ok && ident.Name == "true" {
-						issues = append(issues, r.NewIssue(kv.Pos(), ctx,
+						issues = append(issues, r.NewIssueRange(kv.Pos(), kv.End(), ctx,
 							"Отключение "+key.Name+" может привести к проблемам безопасности или производительности"))
 					}
 				}
@@ -182,6 +452,97 @@ func (r *InsecureHTTPRule) checkHTTPTransport(lit *ast.CompositeLit, ctx *Contex
 	return issues
 }
 
+// isNoopVerifier проверяет, является ли функция проверки сертификата функциональным литералом
+// или функцией файла, тело которой состоит только из return nil
+func (r *InsecureHTTPRule) isNoopVerifier(expr ast.Expr, ctx *Context) bool {
+	switch node := expr.(type) {
+	case *ast.FuncLit:
+		return returnsOnlyNil(node.Body)
+	case *ast.Ident:
+		for _, decl := range ctx.File.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Recv == nil && funcDecl.Name.Name == node.Name {
+				return returnsOnlyNil(funcDecl.Body)
+			}
+		}
+	}
+	return false
+}
+
+// returnsOnlyNil проверяет, состоит ли тело функции из единственного return nil
+func returnsOnlyNil(body *ast.BlockStmt) bool {
+	if body == nil || len(body.List) != 1 {
+		return false
+	}
+	ret, ok := body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return false
+	}
+	ident, ok := ret.Results[0].(*ast.Ident)
+	return ok && ident.Name == "nil"
+}
+
+// compositeLitValue возвращает значение поля составного литерала или nil, если поле не задано
+func compositeLitValue(lit *ast.CompositeLit, name string) ast.Expr {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+				return kv.Value
+			}
+		}
+	}
+	return nil
+}
+
+// compositeLitKey возвращает первое из перечисленных полей, заданное в составном литерале
+func compositeLitKey(lit *ast.CompositeLit, names ...string) string {
+	for _, name := range names {
+		if compositeLitValue(lit, name) != nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// unwrapAddr снимает оператор взятия адреса: &tls.Config{...}
+func unwrapAddr(expr ast.Expr) ast.Expr {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		return unary.X
+	}
+	return expr
+}
+
+// insecureGodebugCall возвращает небезопасную настройку из вызова os.Setenv("GODEBUG", "...")
+func (r *InsecureHTTPRule) insecureGodebugCall(callExpr *ast.CallExpr) string {
+	if astToString(callExpr.Fun) != "os.Setenv" || len(callExpr.Args) != 2 {
+		return ""
+	}
+	name, ok := callExpr.Args[0].(*ast.BasicLit)
+	if !ok || name.Kind != token.STRING || strings.Trim(name.Value, "\"`") != "GODEBUG" {
+		return ""
+	}
+	value, ok := callExpr.Args[1].(*ast.BasicLit)
+	if !ok || value.Kind != token.STRING {
+		return ""
+	}
+	return r.insecureGodebugSetting(strings.Trim(value.Value, "\"`"))
+}
+
+// insecureGodebugSetting возвращает первую настройку GODEBUG, ослабляющую проверку сертификатов
+func (r *InsecureHTTPRule) insecureGodebugSetting(value string) string {
+	for _, setting := range strings.Split(value, ",") {
+		setting = strings.ToLower(strings.TrimSpace(setting))
+		if _, ok := r.insecureGodebugSettings[setting]; ok {
+			return setting
+		}
+	}
+	return ""
+}
+
+// godebugMessage формирует сообщение для небезопасной настройки GODEBUG
+func (r *InsecureHTTPRule) godebugMessage(setting string) string {
+	return "GODEBUG " + setting + " " + r.insecureGodebugSettings[setting] + " и ослабляет проверку сертификатов TLS"
+}
+
 // checkHTTPServer проверяет небезопасные настройки в http.Server
 func (r *InsecureHTTPRule) checkHTTPServer(lit *ast.CompositeLit, ctx *Context) []report.Issue {
 	var issues []report.Issue
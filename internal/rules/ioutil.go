@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"go/ast"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// DeprecatedIoutilRule проверяет использование пакета io/ioutil, устаревшего начиная с Go 1.16
+type DeprecatedIoutilRule struct {
+	BaseRule
+	// Замены функций и переменных пакета ioutil в пакетах os и io
+	replacements map[string]string
+	// Замены с другим типом результата: переименование не компилируется без доработки кода
+	incompatible map[string]string
+}
+
+// NewDeprecatedIoutilRule создает новое правило для проверки использования устаревшего пакета io/ioutil
+func NewDeprecatedIoutilRule() *DeprecatedIoutilRule {
+	return &DeprecatedIoutilRule{
+		BaseRule: BaseRule{
+			id:          "SEC024",
+			description: "Использование устаревшего пакета io/ioutil",
+			severity:    report.SeverityInfo,
+			cwe:         "CWE-477",
+			addedIn:     "0.2.0",
+			remediation: "Замените функции io/ioutil на аналоги из пакетов os и io",
+		},
+		replacements: map[string]string{
+			"ReadAll":   "io.ReadAll",
+			"ReadFile":  "os.ReadFile",
+			"WriteFile": "os.WriteFile",
+			"TempFile":  "os.CreateTemp",
+			"TempDir":   "os.MkdirTemp",
+			"NopCloser": "io.NopCloser",
+			"Discard":   "io.Discard",
+		},
+		incompatible: map[string]string{
+			"ReadDir": "os.ReadDir возвращает []os.DirEntry вместо []os.FileInfo: для размера и времени изменения вызывайте Info()",
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *DeprecatedIoutilRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальное имя пакета io/ioutil с учетом псевдонима импорта
+	ioutilName := importName(ctx, map[string]bool{"io/ioutil": true})
+	if ioutilName == "" || ioutilName == "_" {
+		return issues
+	}
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || !isPackageCall(sel, ioutilName, sel.Sel.Name) {
+			return true
+		}
+
+		if note, ok := r.incompatible[sel.Sel.Name]; ok {
+			// Механическое исправление не предлагается: замена меняет тип результата
+			issues = append(issues, r.NewIssueRange(sel.Pos(), sel.End(), ctx,
+				"ioutil."+sel.Sel.Name+" устарела начиная с Go 1.16; "+note))
+			return true
+		}
+
+		replacement, ok := r.replacements[sel.Sel.Name]
+		if !ok {
+			issues = append(issues, r.NewIssueRange(sel.Pos(), sel.End(), ctx, "Пакет io/ioutil устарел начиная с Go 1.16"))
+			return true
+		}
+
+		issue := r.NewIssueRange(sel.Pos(), sel.End(), ctx,
+			"ioutil."+sel.Sel.Name+" устарела начиная с Go 1.16, используйте "+replacement)
+		pkg, _, _ := strings.Cut(replacement, ".")
+		if importName(ctx, map[string]bool{pkg: true}) == pkg {
+			// Пакет замены уже импортирован под своим именем: замена не добавляет неопределенных имен
+			issue.SuggestedFix = NewSuggestedFix(sel.Pos(), sel.End(), ctx, replacement, "Заменить на "+replacement)
+			issue.SuggestedFix.SafeToApply = true
+		} else {
+			issue.SuggestedFix = NewSuggestedFix(sel.Pos(), sel.End(), ctx, replacement,
+				"Заменить на "+replacement+" (требуется импорт пакета "+pkg+")")
+		}
+		issues = append(issues, issue)
+		return true
+	})
+
+	return issues
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"go/ast"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// InsecureJWTRule проверяет код на разбор JWT без проверки стандартных утверждений (exp, nbf)
+type InsecureJWTRule struct {
+	BaseRule
+	// Пути импорта библиотек JWT
+	jwtPackages []string
+	// Типы, содержащие зарегистрированные утверждения
+	registeredClaimsTypes map[string]bool
+}
+
+// NewInsecureJWTRule создает новое правило для проверки проверки утверждений JWT
+func NewInsecureJWTRule() *InsecureJWTRule {
+	return &InsecureJWTRule{
+		BaseRule: BaseRule{
+			id:          "SEC007",
+			description: "Разбор JWT без проверки стандартных утверждений",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-347",
+			addedIn:     "0.2.0",
+			remediation: "Проверяйте подпись, алгоритм и стандартные утверждения токена (exp, iss, aud) при разборе JWT",
+		},
+		jwtPackages: []string{
+			"github.com/golang-jwt/jwt",
+			"github.com/dgrijalva/jwt-go",
+			"github.com/form3tech-oss/jwt-go",
+		},
+		registeredClaimsTypes: map[string]bool{
+			"RegisteredClaims": true,
+			"StandardClaims":   true,
+			"MapClaims":        true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *InsecureJWTRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Правило активируется только при импорте библиотеки JWT
+	jwtName := r.jwtImportName(ctx)
+	if jwtName == "" {
+		return issues
+	}
+
+	// Собираем объявленные в файле структуры утверждений
+	structTypes := make(map[string]*ast.StructType)
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		if typeSpec, ok := n.(*ast.TypeSpec); ok {
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				structTypes[typeSpec.Name.Name] = structType
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			// Явное отключение проверки утверждений: jwt.WithoutClaimsValidation()
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == jwtName && sel.Sel.Name == "WithoutClaimsValidation" {
+				issues = append(issues, r.NewIssue(node.Pos(), ctx,
+					"Проверка утверждений JWT (exp, nbf) отключена через WithoutClaimsValidation"))
+			}
+
+			// ParseWithClaims со структурой утверждений без зарегистрированных полей
+			if sel.Sel.Name == "ParseWithClaims" && len(node.Args) >= 2 {
+				if typeName := claimsTypeName(node.Args[1]); typeName != "" {
+					if structType, ok := structTypes[typeName]; ok && !r.hasRegisteredClaims(structType, jwtName) {
+						issues = append(issues, r.NewIssue(node.Pos(), ctx,
+							"Структура утверждений "+typeName+" не содержит зарегистрированных утверждений, срок действия токена не проверяется"))
+					}
+				}
+			}
+
+		case *ast.KeyValueExpr:
+			// jwt.Parser{SkipClaimsValidation: true}
+			if key, ok := node.Key.(*ast.Ident); ok && key.Name == "SkipClaimsValidation" {
+				if val, ok := node.Value.(*ast.Ident); ok && val.Name == "true" {
+					issues = append(issues, r.NewIssue(node.Pos(), ctx,
+						"Проверка утверждений JWT (exp, nbf) отключена через SkipClaimsValidation"))
+				}
+			}
+
+		case *ast.AssignStmt:
+			// parser.SkipClaimsValidation = true
+			for i, lhs := range node.Lhs {
+				if i >= len(node.Rhs) {
+					continue
+				}
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && sel.Sel.Name == "SkipClaimsValidation" {
+					if val, ok := node.Rhs[i].(*ast.Ident); ok && val.Name == "true" {
+						issues = append(issues, r.NewIssue(node.Pos(), ctx,
+							"Проверка утверждений JWT (exp, nbf) отключена через SkipClaimsValidation"))
+					}
+				}
+			}
+		}
+
+		return true
+	})
+
+	return issues
+}
+
+// jwtImportName возвращает локальное имя импортированного пакета JWT или пустую строку
+func (r *InsecureJWTRule) jwtImportName(ctx *Context) string {
+	for _, imp := range ctx.File.Imports {
+		if imp.Path == nil {
+			continue
+		}
+
+		path := strings.Trim(imp.Path.Value, `"`)
+		for _, pkg := range r.jwtPackages {
+			if strings.HasPrefix(path, pkg) {
+				if imp.Name != nil {
+					return imp.Name.Name
+				}
+				return "jwt"
+			}
+		}
+	}
+	return ""
+}
+
+// hasRegisteredClaims проверяет, встраивает ли структура зарегистрированные утверждения JWT
+func (r *InsecureJWTRule) hasRegisteredClaims(structType *ast.StructType, jwtName string) bool {
+	for _, field := range structType.Fields.List {
+		// Интересуют только встроенные поля
+		if len(field.Names) > 0 {
+			continue
+		}
+
+		fieldType := field.Type
+		if star, ok := fieldType.(*ast.StarExpr); ok {
+			fieldType = star.X
+		}
+
+		if sel, ok := fieldType.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == jwtName && r.registeredClaimsTypes[sel.Sel.Name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// claimsTypeName возвращает имя локального типа утверждений из выражения &Claims{} или new(Claims)
+func claimsTypeName(expr ast.Expr) string {
+	switch node := expr.(type) {
+	case *ast.UnaryExpr:
+		if lit, ok := node.X.(*ast.CompositeLit); ok {
+			if ident, ok := lit.Type.(*ast.Ident); ok {
+				return ident.Name
+			}
+		}
+	case *ast.CallExpr:
+		if fn, ok := node.Fun.(*ast.Ident); ok && fn.Name == "new" && len(node.Args) == 1 {
+			if ident, ok := node.Args[0].(*ast.Ident); ok {
+				return ident.Name
+			}
+		}
+	}
+	return ""
+}
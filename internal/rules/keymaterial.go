@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+
+	"go-audit/pkg/report"
+)
+
+// HardcodedKeyMaterialRule проверяет строковые литералы на закрытые ключи PEM, сертификаты и токены JWT
+// независимо от имени переменной
+type HardcodedKeyMaterialRule struct {
+	BaseRule
+	// Заголовок закрытого ключа PEM
+	privateKeyRegex *regexp.Regexp
+	// Заголовки сертификата и открытого ключа, которые не являются секретом
+	certificateRegex *regexp.Regexp
+	publicKeyRegex   *regexp.Regexp
+	// Три сегмента base64url, первые два начинаются с закодированного "{"
+	jwtRegex *regexp.Regexp
+}
+
+// NewHardcodedKeyMaterialRule создает новое правило для проверки ключевого материала в литералах
+func NewHardcodedKeyMaterialRule() *HardcodedKeyMaterialRule {
+	return &HardcodedKeyMaterialRule{
+		BaseRule: BaseRule{
+			id:          "SEC016",
+			description: "Закрытый ключ или токен JWT в исходном коде",
+			severity:    report.SeverityCritical,
+			cwe:         "CWE-321",
+			addedIn:     "0.2.0",
+			remediation: "Удалите ключ из репозитория, отзовите его и загружайте ключи из защищенного хранилища",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Key_Management_Cheat_Sheet.html"},
+		},
+		privateKeyRegex:  regexp.MustCompile(`-----BEGIN ((RSA|EC|DSA|OPENSSH|ENCRYPTED) )?PRIVATE KEY-----`),
+		certificateRegex: regexp.MustCompile(`-----BEGIN CERTIFICATE-----`),
+		publicKeyRegex:   regexp.MustCompile(`-----BEGIN (RSA )?PUBLIC KEY-----`),
+		jwtRegex:         regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.eyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`),
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *HardcodedKeyMaterialRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		lit, ok := n.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			value = lit.Value
+		}
+
+		switch {
+		case r.privateKeyRegex.MatchString(value):
+			issues = append(issues, r.NewIssue(lit.Pos(), ctx,
+				"Закрытый ключ в формате PEM в исходном коде, загружайте ключ из защищенного хранилища или файла вне репозитория"))
+
+		case r.jwtRegex.MatchString(value):
+			issues = append(issues, r.NewIssue(lit.Pos(), ctx,
+				"Токен JWT в исходном коде, получайте токен во время выполнения"))
+
+		case r.certificateRegex.MatchString(value):
+			// Сертификат не является секретом, но его замена требует пересборки
+			issue := r.NewIssue(lit.Pos(), ctx,
+				"Сертификат в формате PEM в исходном коде, рассмотрите загрузку из файла конфигурации")
+			issue.Severity = report.SeverityLow
+			issues = append(issues, issue)
+
+		case r.publicKeyRegex.MatchString(value):
+			issue := r.NewIssue(lit.Pos(), ctx,
+				"Открытый ключ в формате PEM в исходном коде")
+			issue.Severity = report.SeverityInfo
+			issues = append(issues, issue)
+		}
+		return true
+	})
+
+	return issues
+}
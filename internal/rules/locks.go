@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"go/ast"
+
+	"go-audit/pkg/report"
+)
+
+// UnbalancedLockRule проверяет функции, захватывающие мьютекс без последующего освобождения:
+// выход из функции с удерживаемой блокировкой приводит к взаимоблокировке
+type UnbalancedLockRule struct {
+	BaseRule
+	// Методы захвата sync.Mutex и sync.RWMutex и соответствующие им методы освобождения
+	unlockMethods map[string]string
+}
+
+// NewUnbalancedLockRule создает новое правило для проверки захваченных и не освобожденных блокировок
+func NewUnbalancedLockRule() *UnbalancedLockRule {
+	return &UnbalancedLockRule{
+		BaseRule: BaseRule{
+			id:          "SEC025",
+			description: "Блокировка не освобождается в функции",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-667",
+			addedIn:     "0.2.0",
+			remediation: "Освобождайте блокировку через defer mu.Unlock() сразу после захвата",
+		},
+		unlockMethods: map[string]string{
+			"Lock":  "Unlock",
+			"RLock": "RUnlock",
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *UnbalancedLockRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		// Методы Lock и RLock оберток над мьютексом захватывают блокировку для вызывающего кода
+		if _, ok := r.unlockMethods[funcDecl.Name.Name]; ok {
+			continue
+		}
+
+		// Эвристика: блокировка считается освобождаемой, если в функции есть хотя бы один вызов
+		// парного метода для того же мьютекса, отложенный или явный
+		var locks []*ast.CallExpr
+		unlocked := make(map[string]bool)
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			if _, ok := r.unlockMethods[sel.Sel.Name]; ok {
+				locks = append(locks, call)
+			} else {
+				unlocked[astToString(sel.X)+"."+sel.Sel.Name] = true
+			}
+			return true
+		})
+
+		for _, call := range locks {
+			sel := call.Fun.(*ast.SelectorExpr)
+			mutex := astToString(sel.X)
+			unlock := r.unlockMethods[sel.Sel.Name]
+			if unlocked[mutex+"."+unlock] {
+				continue
+			}
+
+			issues = append(issues, r.NewIssueRange(call.Pos(), call.End(), ctx,
+				"Блокировка "+mutex+"."+sel.Sel.Name+"() не освобождается в функции "+funcDecl.Name.Name+
+					", что может привести к взаимоблокировке; добавьте defer "+mutex+"."+unlock+"() сразу после захвата"))
+		}
+	}
+
+	return issues
+}
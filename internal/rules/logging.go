@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"go/ast"
+)
+
+// loggingFuncs функции логирования и печати стандартных пакетов log и fmt
+var loggingFuncs = map[string]map[string]bool{
+	"log": {
+		"Fatal":  true,
+		"Fatalf": true,
+		"Print":  true,
+		"Printf": true,
+		"Panic":  true,
+		"Panicf": true,
+	},
+	"fmt": {
+		"Print":    true,
+		"Printf":   true,
+		"Println":  true,
+		"Sprint":   true,
+		"Sprintf":  true,
+		"Sprintln": true,
+	},
+}
+
+// loggingLevelFuncs методы уровней логирования, общие для библиотек логирования
+var loggingLevelFuncs = map[string]bool{
+	"Error":  true,
+	"Errorf": true,
+	"Warn":   true,
+	"Warnf":  true,
+	"Info":   true,
+	"Infof":  true,
+	"Debug":  true,
+	"Debugf": true,
+}
+
+// defaultLoggingPackages имена пакетов и переменных популярных библиотек логирования
+var defaultLoggingPackages = []string{"logger", "logging", "logrus", "zap", "zerolog"}
+
+// IsLoggingCall проверяет, является ли вызов функцией логирования: печатью пакетов log и fmt
+// или методом уровня логирования популярных библиотек (logrus, zap, zerolog)
+func IsLoggingCall(sel *ast.SelectorExpr) bool {
+	return isLoggingCall(sel, defaultLoggingPackages)
+}
+
+// loggingCallMatcher возвращает проверку вызовов логирования с учетом настройки loggingPackages
+// текущего правила: перечисленные в ней имена пакетов и переменных дополняют библиотеки по умолчанию
+func loggingCallMatcher(ctx *Context) func(sel *ast.SelectorExpr) bool {
+	extra := ctx.StringSliceSetting("loggingPackages", nil)
+	if len(extra) == 0 {
+		return IsLoggingCall
+	}
+
+	packages := append(append([]string{}, defaultLoggingPackages...), extra...)
+	return func(sel *ast.SelectorExpr) bool {
+		return isLoggingCall(sel, packages)
+	}
+}
+
+// isLoggingCall проверяет вызов функции логирования для заданных библиотек логирования
+func isLoggingCall(sel *ast.SelectorExpr, packages []string) bool {
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	if loggingFuncs[x.Name][sel.Sel.Name] {
+		return true
+	}
+	if !loggingLevelFuncs[sel.Sel.Name] {
+		return false
+	}
+	for _, pkg := range packages {
+		if x.Name == pkg {
+			return true
+		}
+	}
+	return false
+}
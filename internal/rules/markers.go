@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"go/ast"
+	"regexp"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// SecurityMarkerRule сопоставляет комментарии TODO/FIXME о безопасности с кодом, к которому они относятся
+type SecurityMarkerRule struct {
+	BaseRule
+	// Регулярное выражение для поиска маркеров безопасности в комментариях
+	markerRegex *regexp.Regexp
+	// Регулярное выражение для слов идентификатора, относящихся к проверкам авторизации
+	authRegex *regexp.Regexp
+	// Пакеты, вызовы которых считаются криптографическими операциями
+	cryptoPackages map[string]bool
+	// Поля конфигурации, влияющие на безопасность соединения
+	securityFields map[string]bool
+}
+
+// NewSecurityMarkerRule создает новое правило для проверки маркеров безопасности
+func NewSecurityMarkerRule() *SecurityMarkerRule {
+	return &SecurityMarkerRule{
+		BaseRule: BaseRule{
+			id:          "SEC009",
+			description: "Незавершенная доработка безопасности в коде",
+			severity:    report.SeverityInfo,
+			cwe:         "CWE-546",
+			addedIn:     "0.2.0",
+			remediation: "Завершите доработку безопасности или заведите задачу и удалите маркер из кода",
+		},
+		markerRegex: regexp.MustCompile(`(?i)\b(TODO|FIXME|HACK|XXX)\b.*\b(security|secure|insecure|auth(?:entication|enticate|orization|orize|z|n)?|verify|tls|ssl|crypto|password|secret|token|csrf|xss|sql)\b`),
+		// Слова сравниваются целиком, чтобы author, tokenizer и roleplay не считались проверками авторизации
+		authRegex: regexp.MustCompile(`^(auth[nz]?|authenticat(e|ed|es|ion|or)|authori[sz](e|ed|es|ation|er)|tokens?|passwords?|permissions?|verif(y|ied|ies)|roles?|admins?)$`),
+		cryptoPackages: map[string]bool{
+			"md5":    true,
+			"sha1":   true,
+			"sha256": true,
+			"sha512": true,
+			"des":    true,
+			"rc4":    true,
+			"aes":    true,
+			"cipher": true,
+			"rsa":    true,
+			"ecdsa":  true,
+			"hmac":   true,
+			"bcrypt": true,
+			"tls":    true,
+			"x509":   true,
+		},
+		securityFields: map[string]bool{
+			"InsecureSkipVerify":    true,
+			"MinVersion":            true,
+			"MaxVersion":            true,
+			"CipherSuites":          true,
+			"TLSConfig":             true,
+			"TLSClientConfig":       true,
+			"VerifyPeerCertificate": true,
+			"RootCAs":               true,
+			"ClientAuth":            true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *SecurityMarkerRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Индексируем внешние узлы по строке, на которой они начинаются
+	nodesByLine := make(map[int]ast.Node)
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.BlockStmt:
+			return true
+		case ast.Stmt, *ast.KeyValueExpr, *ast.ValueSpec:
+			line := ctx.FileSet.Position(n.Pos()).Line
+			if _, exists := nodesByLine[line]; !exists {
+				nodesByLine[line] = n
+			}
+		}
+		return true
+	})
+
+	for _, group := range ctx.File.Comments {
+		text := strings.TrimSpace(group.Text())
+		if !r.markerRegex.MatchString(text) {
+			continue
+		}
+
+		// Маркер должен находиться непосредственно над аннотируемым кодом
+		endLine := ctx.FileSet.Position(group.End()).Line
+		node, ok := nodesByLine[endLine+1]
+		if !ok {
+			continue
+		}
+
+		if target := r.securityTarget(node); target != "" {
+			marker := strings.SplitN(text, "\n", 2)[0]
+			issues = append(issues, r.NewIssue(node.Pos(), ctx,
+				"Комментарий \""+marker+"\" относится к коду безопасности ("+target+"), доработка не завершена"))
+		}
+	}
+
+	return issues
+}
+
+// isAuthName проверяет, содержит ли идентификатор слово, относящееся к проверкам авторизации:
+// isAdmin и verifyToken подходят, author и administrative - нет
+func (r *SecurityMarkerRule) isAuthName(name string) bool {
+	for _, word := range nameWords(name) {
+		if r.authRegex.MatchString(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// securityTarget возвращает описание кода безопасности в узле или пустую строку
+func (r *SecurityMarkerRule) securityTarget(node ast.Node) string {
+	// Проверка авторизации в условии
+	if ifStmt, ok := node.(*ast.IfStmt); ok {
+		var target string
+		ast.Inspect(ifStmt.Cond, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && r.isAuthName(ident.Name) {
+				target = "проверка авторизации " + ident.Name
+				return false
+			}
+			return target == ""
+		})
+		if target != "" {
+			return target
+		}
+	}
+
+	var target string
+	ast.Inspect(node, func(n ast.Node) bool {
+		if target != "" {
+			return false
+		}
+
+		switch expr := n.(type) {
+		case *ast.KeyValueExpr:
+			// Настройки TLS
+			if key, ok := expr.Key.(*ast.Ident); ok && r.securityFields[key.Name] {
+				target = key.Name
+			}
+
+		case *ast.SelectorExpr:
+			// Присваивание настроек TLS: cfg.InsecureSkipVerify = true
+			if r.securityFields[expr.Sel.Name] {
+				target = expr.Sel.Name
+			}
+
+		case *ast.CallExpr:
+			// Криптографические вызовы
+			if sel, ok := expr.Fun.(*ast.SelectorExpr); ok {
+				if x, ok := sel.X.(*ast.Ident); ok && r.cryptoPackages[x.Name] {
+					target = x.Name + "." + sel.Sel.Name
+				}
+			}
+		}
+		return true
+	})
+
+	return target
+}
@@ -0,0 +1,165 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// defaultMaxMultipartMemory лимит памяти для ParseMultipartForm по умолчанию (32 МБ)
+const defaultMaxMultipartMemory = 32 << 20
+
+// MultipartLimitRule проверяет обработку multipart-форм на неограниченное потребление памяти
+type MultipartLimitRule struct {
+	BaseRule
+	// Функции чтения всего содержимого в память
+	readAllFuncs map[string]bool
+}
+
+// NewMultipartLimitRule создает новое правило для проверки лимитов multipart-форм
+func NewMultipartLimitRule() *MultipartLimitRule {
+	return &MultipartLimitRule{
+		BaseRule: BaseRule{
+			id:          "SEC011",
+			description: "Небезопасный лимит памяти multipart-формы",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-770",
+			addedIn:     "0.2.0",
+			remediation: "Ограничьте память ParseMultipartForm разумным значением, а размер тела запроса — через http.MaxBytesReader",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/File_Upload_Cheat_Sheet.html"},
+		},
+		readAllFuncs: map[string]bool{
+			"ioutil.ReadAll": true,
+			"io.ReadAll":     true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *MultipartLimitRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	maxMemory := int64(ctx.IntSetting("maxMemory", defaultMaxMultipartMemory))
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		// Загруженные файлы и заголовки файлов из формы
+		uploadedFiles := make(map[string]bool)
+		fileHeaders := make(map[string]bool)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.AssignStmt:
+				if len(node.Rhs) != 1 {
+					return true
+				}
+				callExpr, ok := node.Rhs[0].(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+
+				switch {
+				case sel.Sel.Name == "FormFile":
+					// file, header, err := r.FormFile("upload")
+					if ident, ok := node.Lhs[0].(*ast.Ident); ok && ident.Name != "_" {
+						uploadedFiles[ident.Name] = true
+					}
+					if len(node.Lhs) > 1 {
+						if ident, ok := node.Lhs[1].(*ast.Ident); ok && ident.Name != "_" {
+							fileHeaders[ident.Name] = true
+						}
+					}
+
+				case sel.Sel.Name == "Open" && len(callExpr.Args) == 0:
+					// f, err := header.Open()
+					if x, ok := sel.X.(*ast.Ident); ok && fileHeaders[x.Name] {
+						if ident, ok := node.Lhs[0].(*ast.Ident); ok && ident.Name != "_" {
+							uploadedFiles[ident.Name] = true
+						}
+					}
+				}
+
+			case *ast.CallExpr:
+				sel, ok := node.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+
+				// r.ParseMultipartForm(0)
+				if sel.Sel.Name == "ParseMultipartForm" && len(node.Args) == 1 {
+					if value, ok := intConstValue(node.Args[0]); ok {
+						if value <= 0 {
+							issues = append(issues, r.NewIssue(node.Pos(), ctx,
+								"ParseMultipartForm вызывается с нулевым лимитом памяти, укажите ограничение"))
+						} else if value > maxMemory {
+							issues = append(issues, r.NewIssue(node.Pos(), ctx,
+								"Лимит памяти ParseMultipartForm ("+strconv.FormatInt(value, 10)+" байт) превышает "+strconv.FormatInt(maxMemory, 10)+" байт"))
+						}
+					}
+					return true
+				}
+
+				// ioutil.ReadAll(file)
+				if x, ok := sel.X.(*ast.Ident); ok && r.readAllFuncs[x.Name+"."+sel.Sel.Name] && len(node.Args) == 1 {
+					if ident, ok := node.Args[0].(*ast.Ident); ok && uploadedFiles[ident.Name] {
+						issues = append(issues, r.NewIssue(node.Pos(), ctx,
+							"Загруженный файл "+ident.Name+" читается в память целиком, используйте потоковую обработку или io.LimitReader"))
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return issues
+}
+
+// intConstValue вычисляет значение целочисленного константного выражения из литералов
+func intConstValue(expr ast.Expr) (int64, bool) {
+	switch node := expr.(type) {
+	case *ast.BasicLit:
+		if node.Kind != token.INT {
+			return 0, false
+		}
+		value, err := strconv.ParseInt(strings.ReplaceAll(node.Value, "_", ""), 0, 64)
+		return value, err == nil
+
+	case *ast.ParenExpr:
+		return intConstValue(node.X)
+
+	case *ast.BinaryExpr:
+		x, ok := intConstValue(node.X)
+		if !ok {
+			return 0, false
+		}
+		y, ok := intConstValue(node.Y)
+		if !ok {
+			return 0, false
+		}
+
+		switch node.Op {
+		case token.SHL:
+			return x << uint64(y), true
+		case token.SHR:
+			return x >> uint64(y), true
+		case token.MUL:
+			return x * y, true
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"go/ast"
+
+	"go-audit/pkg/report"
+)
+
+// InsecureRandomRule проверяет использование math/rand для генерации токенов, паролей и ключей
+type InsecureRandomRule struct {
+	BaseRule
+	// Чувствительные имена из правила поиска секретов
+	secrets *HardcodedSecretsRule
+	// Дополнительные слова в именах значений, которые должны быть непредсказуемыми
+	randomSensitiveNames map[string]bool
+}
+
+// NewInsecureRandomRule создает новое правило для проверки использования math/rand вместо crypto/rand
+func NewInsecureRandomRule() *InsecureRandomRule {
+	return &InsecureRandomRule{
+		BaseRule: BaseRule{
+			id:          "SEC013",
+			description: "Использование math/rand для генерации секретных значений",
+			severity:    report.SeverityHigh,
+			cwe:         "CWE-338",
+			addedIn:     "0.2.0",
+			remediation: "Используйте crypto/rand для генерации ключей, токенов и других секретных значений",
+		},
+		secrets: NewHardcodedSecretsRule(),
+		randomSensitiveNames: map[string]bool{
+			"session": true,
+			"sessid":  true,
+			"nonce":   true,
+			"salt":    true,
+			"otp":     true,
+			"totp":    true,
+			"hotp":    true,
+			"key":     true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *InsecureRandomRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Различаем math/rand и crypto/rand по пути импорта, а не по имени пакета
+	randName := mathRandImportName(ctx)
+	if randName == "" {
+		return issues
+	}
+
+	for _, decl := range ctx.File.Decls {
+		switch node := decl.(type) {
+		case *ast.FuncDecl:
+			if node.Body != nil {
+				issues = append(issues, r.checkFunc(node, randName, ctx)...)
+			}
+		case *ast.GenDecl:
+			// Глобальные переменные: var sessionKey = rand.Int63()
+			for _, spec := range node.Specs {
+				if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+					issues = append(issues, r.checkValueSpec(valueSpec, randName, ctx)...)
+				}
+			}
+		}
+	}
+
+	return issues
+}
+
+// checkFunc проверяет тело функции на попадание значений math/rand в чувствительные переменные
+func (r *InsecureRandomRule) checkFunc(funcDecl *ast.FuncDecl, randName string, ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				if i >= len(node.Rhs) {
+					continue
+				}
+				name := assignedName(lhs)
+				if r.isSensitive(name) && containsPackageCall(node.Rhs[i], randName) {
+					issues = append(issues, r.NewIssue(node.Pos(), ctx,
+						"Значение "+name+" генерируется через math/rand и предсказуемо, используйте crypto/rand"))
+				}
+			}
+
+		case *ast.ValueSpec:
+			issues = append(issues, r.checkValueSpec(node, randName, ctx)...)
+
+		case *ast.ReturnStmt:
+			// func generateToken() string { return strconv.Itoa(rand.Int()) }
+			if !r.isSensitive(funcDecl.Name.Name) {
+				return true
+			}
+			for _, result := range node.Results {
+				if containsPackageCall(result, randName) {
+					issues = append(issues, r.NewIssue(node.Pos(), ctx,
+						"Функция "+funcDecl.Name.Name+" возвращает значение из math/rand, используйте crypto/rand"))
+					break
+				}
+			}
+
+		case *ast.CallExpr:
+			// rand.Read(token) из math/rand
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Read" || len(node.Args) != 1 {
+				return true
+			}
+			if x, ok := sel.X.(*ast.Ident); !ok || x.Name != randName {
+				return true
+			}
+			if name := assignedName(node.Args[0]); r.isSensitive(name) {
+				issues = append(issues, r.NewIssue(node.Pos(), ctx,
+					"Срез "+name+" заполняется через math/rand.Read и предсказуем, используйте crypto/rand.Read"))
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// checkValueSpec проверяет объявления переменных со значениями из math/rand
+func (r *InsecureRandomRule) checkValueSpec(spec *ast.ValueSpec, randName string, ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	for i, value := range spec.Values {
+		if i >= len(spec.Names) {
+			continue
+		}
+		name := spec.Names[i].Name
+		if r.isSensitive(name) && containsPackageCall(value, randName) {
+			issues = append(issues, r.NewIssue(spec.Pos(), ctx,
+				"Значение "+name+" генерируется через math/rand и предсказуемо, используйте crypto/rand"))
+		}
+	}
+
+	return issues
+}
+
+// isSensitive проверяет, должно ли значение с таким именем быть непредсказуемым
+func (r *InsecureRandomRule) isSensitive(name string) bool {
+	if name == "" || name == "_" {
+		return false
+	}
+	// Слова сравниваются целиком, чтобы footprint, hotpath и monkey не считались секретами
+	return r.secrets.hasSensitiveWord(name) || hasNameWord(name, r.randomSensitiveNames)
+}
+
+// assignedName возвращает имя переменной или поля, которому присваивается значение:
+// token, s.Token или token[i]
+func assignedName(expr ast.Expr) string {
+	switch node := expr.(type) {
+	case *ast.Ident:
+		return node.Name
+	case *ast.SelectorExpr:
+		return node.Sel.Name
+	case *ast.IndexExpr:
+		return assignedName(node.X)
+	case *ast.SliceExpr:
+		return assignedName(node.X)
+	case *ast.StarExpr:
+		return assignedName(node.X)
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// OpenRedirectRule проверяет передачу пользовательского ввода в http.Redirect без проверки адреса
+type OpenRedirectRule struct {
+	BaseRule
+}
+
+// NewOpenRedirectRule создает новое правило для проверки открытых перенаправлений
+func NewOpenRedirectRule() *OpenRedirectRule {
+	return &OpenRedirectRule{
+		BaseRule: BaseRule{
+			id:          "SEC019",
+			description: "Перенаправление на адрес из пользовательского ввода",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-601",
+			addedIn:     "0.2.0",
+			remediation: "Перенаправляйте только на относительные пути или адреса из списка разрешенных",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Unvalidated_Redirects_and_Forwards_Cheat_Sheet.html"},
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *OpenRedirectRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальное имя net/http с учетом псевдонима импорта
+	httpName := importName(ctx, map[string]bool{"net/http": true})
+	if httpName == "" {
+		return issues
+	}
+
+	tracker := NewTaintTracker(ctx)
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		tainted := tracker.Propagate(funcDecl.Body)
+		validated := collectRelativePathChecks(funcDecl.Body)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || astToString(call.Fun) != httpName+".Redirect" || len(call.Args) < 3 {
+				return true
+			}
+
+			target := call.Args[2]
+			if !tainted.Derives(target) || isValidatedPath(target, validated) || hasRelativePathPrefix(target) {
+				return true
+			}
+
+			issues = append(issues, r.NewIssue(call.Pos(), ctx,
+				"Открытое перенаправление: адрес в http.Redirect формируется из пользовательского ввода, "+
+					"разрешайте только относительные пути или адреса из списка допустимых"))
+			return true
+		})
+	}
+
+	return issues
+}
+
+// collectRelativePathChecks находит переменные, проверенные на относительный путь:
+// strings.HasPrefix(next, "/") вместе с отклонением префиксов "//" и "/\", strings.HasPrefix(next, "/app/")
+// или разбор url.Parse(next) с проверкой u.Host
+func collectRelativePathChecks(body *ast.BlockStmt) map[string]bool {
+	validated := make(map[string]bool)
+	// Разобранные адреса и исходные переменные: u, err := url.Parse(next)
+	parsed := make(map[string]string)
+	// Переменные с проверкой strings.HasPrefix(next, "/") и проверенные префиксы "//" и "/\"
+	slashChecked := make(map[string]bool)
+	hostPrefixes := make(map[string]map[string]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Rhs) != 1 || len(node.Lhs) == 0 {
+				return true
+			}
+			call, ok := node.Rhs[0].(*ast.CallExpr)
+			if !ok || astToString(call.Fun) != "url.Parse" || len(call.Args) != 1 {
+				return true
+			}
+			u, ok := node.Lhs[0].(*ast.Ident)
+			source, ok2 := call.Args[0].(*ast.Ident)
+			if ok && ok2 {
+				parsed[u.Name] = source.Name
+			}
+
+		case *ast.CallExpr:
+			if astToString(node.Fun) != "strings.HasPrefix" || len(node.Args) != 2 {
+				return true
+			}
+			ident, ok := node.Args[0].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			prefix, ok := node.Args[1].(*ast.BasicLit)
+			if !ok || prefix.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(prefix.Value)
+			if err != nil || !strings.HasPrefix(value, "/") {
+				return true
+			}
+			switch {
+			case value == "/":
+				slashChecked[ident.Name] = true
+			case value == "//" || value == `/\`:
+				// Проверка префиксов адреса другого хоста: !strings.HasPrefix(next, "//")
+				if hostPrefixes[ident.Name] == nil {
+					hostPrefixes[ident.Name] = make(map[string]bool)
+				}
+				hostPrefixes[ident.Name][value] = true
+			case value[1] != '/' && value[1] != '\\':
+				// Префикс с путем ("/app/") сам исключает "//" и "/\"
+				validated[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	// Проверка префикса "/" пропускает "//evil.com" и "/\evil.com", которые браузер трактует
+	// как адрес другого хоста, поэтому она засчитывается только вместе с проверкой обоих префиксов
+	for name := range slashChecked {
+		if rejected := hostPrefixes[name]; rejected["//"] && rejected[`/\`] {
+			validated[name] = true
+		}
+	}
+
+	// u.Host проверяет, что адрес не ведет на другой хост; u.IsAbs() для этого недостаточно:
+	// у "//evil.com" нет схемы, но есть хост
+	ast.Inspect(body, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Host" {
+			return true
+		}
+		if u, ok := sel.X.(*ast.Ident); ok {
+			if source, ok := parsed[u.Name]; ok {
+				validated[source] = true
+				validated[u.Name] = true
+			}
+		}
+		return true
+	})
+
+	return validated
+}
+
+// hasRelativePathPrefix проверяет, начинается ли адрес с литерала относительного пути:
+// "/orders/" + id. Префиксы "//" и "/\" исключаются, так как браузер трактует их как адрес другого хоста.
+func hasRelativePathPrefix(expr ast.Expr) bool {
+	for {
+		bin, ok := expr.(*ast.BinaryExpr)
+		if !ok || bin.Op != token.ADD {
+			break
+		}
+		expr = bin.X
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil || len(value) < 2 {
+		return false
+	}
+	return value[0] == '/' && value[1] != '/' && value[1] != '\\'
+}
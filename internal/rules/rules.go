@@ -1,8 +1,12 @@
 package rules
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
 
 	"go-audit/pkg/config"
 	"go-audit/pkg/report"
@@ -17,6 +21,96 @@ type Context struct {
 	FileDir     string
 	FileContent []byte
 	Package     string
+	// Все файлы пакета, разобранные вместе с текущим (включая File)
+	PackageFiles []*ast.File
+	// Информация о типах; nil или неполная, если проверка типов недоступна
+	TypesInfo *types.Info
+	// Настройки текущего правила из ruleSettings
+	Settings map[string]interface{}
+	// Родительские узлы AST файла; строится при первом обращении к Parent, если не задан
+	Parents map[ast.Node]ast.Node
+}
+
+// Parent возвращает родительский узел для данного узла в AST текущего файла
+func (c *Context) Parent(node ast.Node) (ast.Node, bool) {
+	if c.Parents == nil {
+		c.Parents = BuildParentMap(c.File)
+	}
+	parent, ok := c.Parents[node]
+	return parent, ok
+}
+
+// BuildParentMap строит индекс родительских узлов за один обход AST
+func BuildParentMap(root ast.Node) map[ast.Node]ast.Node {
+	parents := make(map[ast.Node]ast.Node)
+	var stack []ast.Node
+
+	ast.Inspect(root, func(n ast.Node) bool {
+		if n == nil {
+			// Выход из узла
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		if len(stack) > 0 {
+			parents[n] = stack[len(stack)-1]
+		}
+		stack = append(stack, n)
+		return true
+	})
+
+	return parents
+}
+
+// Files возвращает все файлы пакета или только текущий файл, если пакет не разбирался целиком
+func (c *Context) Files() []*ast.File {
+	if len(c.PackageFiles) > 0 {
+		return c.PackageFiles
+	}
+	return []*ast.File{c.File}
+}
+
+// IntSetting возвращает целочисленную настройку текущего правила или значение по умолчанию
+func (c *Context) IntSetting(key string, defaultValue int) int {
+	switch value := c.Settings[key].(type) {
+	case float64:
+		return int(value)
+	case int:
+		return value
+	case int64:
+		return int(value)
+	}
+	return defaultValue
+}
+
+// FloatSetting возвращает дробную настройку текущего правила или значение по умолчанию
+func (c *Context) FloatSetting(key string, defaultValue float64) float64 {
+	switch value := c.Settings[key].(type) {
+	case float64:
+		return value
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	}
+	return defaultValue
+}
+
+// StringSliceSetting возвращает списочную настройку текущего правила или значение по умолчанию.
+// Нестроковые элементы списка пропускаются.
+func (c *Context) StringSliceSetting(key string, defaultValue []string) []string {
+	switch value := c.Settings[key].(type) {
+	case []string:
+		return value
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	}
+	return defaultValue
 }
 
 // Rule представляет правило безопасности, которое можно проверить
@@ -39,6 +133,14 @@ type BaseRule struct {
 	id          string
 	description string
 	severity    report.Severity
+	cwe         string
+	addedIn     string
+	// Рекомендация по исправлению и ссылки на материалы, дополняющие ссылку на CWE
+	remediation string
+	references  []string
+	// Пакеты, без импорта которых правило не применяется к файлу;
+	// подходит импорт самого пакета или вложенного в него
+	requiredImports []string
 }
 
 // ID возвращает идентификатор правила
@@ -56,9 +158,60 @@ func (r *BaseRule) Severity() report.Severity {
 	return r.severity
 }
 
-// NewIssue создает новую проблему с информацией о правиле
+// CWE возвращает идентификатор CWE, к которому относится правило
+func (r *BaseRule) CWE() string {
+	return r.cwe
+}
+
+// AddedIn возвращает версию, в которой появилось правило
+func (r *BaseRule) AddedIn() string {
+	return r.addedIn
+}
+
+// Remediation возвращает рекомендацию по исправлению проблем правила
+func (r *BaseRule) Remediation() string {
+	return r.remediation
+}
+
+// References возвращает ссылки на описание проблемы: страницу CWE и дополнительные материалы правила
+func (r *BaseRule) References() []string {
+	var references []string
+	if number, ok := strings.CutPrefix(r.cwe, "CWE-"); ok {
+		references = append(references, "https://cwe.mitre.org/data/definitions/"+number+".html")
+	}
+	return append(references, r.references...)
+}
+
+// Applicable проверяет, применимо ли правило к файлу: файл должен импортировать один из пакетов requiredImports.
+// Правило без requiredImports применимо к любому файлу.
+func (r *BaseRule) Applicable(ctx *Context) bool {
+	if len(r.requiredImports) == 0 {
+		return true
+	}
+	for _, imp := range ctx.File.Imports {
+		if imp.Path == nil {
+			continue
+		}
+		path := strings.Trim(imp.Path.Value, `"`)
+		for _, required := range r.requiredImports {
+			if path == required || strings.HasPrefix(path, required+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NewIssue создает новую проблему с информацией о правиле в одной позиции
 func (r *BaseRule) NewIssue(pos token.Pos, ctx *Context, message string) report.Issue {
-	position := ctx.FileSet.Position(pos)
+	return r.NewIssueRange(pos, pos, ctx, message)
+}
+
+// NewIssueRange создает новую проблему с диапазоном позиций от start до end,
+// например для всего узла AST: r.NewIssueRange(node.Pos(), node.End(), ctx, message)
+func (r *BaseRule) NewIssueRange(start, end token.Pos, ctx *Context, message string) report.Issue {
+	position := ctx.FileSet.Position(start)
+	endPosition := ctx.FileSet.Position(end)
 
 	return report.Issue{
 		RuleID:      r.id,
@@ -66,7 +219,108 @@ func (r *BaseRule) NewIssue(pos token.Pos, ctx *Context, message string) report.
 		FilePath:    ctx.FilePath,
 		Line:        position.Line,
 		Column:      position.Column,
+		EndLine:     endPosition.Line,
+		EndColumn:   endPosition.Column,
 		Message:     message,
 		Description: r.description,
+		CWE:         r.cwe,
+		Remediation: r.remediation,
+		References:  r.References(),
+		Snippet:     sourceSnippet(ctx.FileContent, position.Line),
+	}
+}
+
+// NewSuggestedFix создает исправление, заменяющее текст файла от start до end на replacement
+func NewSuggestedFix(start, end token.Pos, ctx *Context, replacement, description string) *report.SuggestedFix {
+	return &report.SuggestedFix{
+		Description: description,
+		StartOffset: ctx.FileSet.Position(start).Offset,
+		EndOffset:   ctx.FileSet.Position(end).Offset,
+		Replacement: replacement,
+	}
+}
+
+// snippetContextLines количество строк контекста до и после строки с проблемой
+const snippetContextLines = 1
+
+// sourceSnippet возвращает фрагмент исходного кода вокруг строки с номерами строк.
+// Строка с проблемой отмечается символом ">".
+func sourceSnippet(content []byte, line int) string {
+	if len(content) == 0 || line <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if line > len(lines) {
+		return ""
+	}
+
+	start := line - snippetContextLines
+	if start < 1 {
+		start = 1
+	}
+	end := line + snippetContextLines
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	width := len(strconv.Itoa(end))
+	var builder strings.Builder
+	for n := start; n <= end; n++ {
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&builder, "%s %*d | %s\n", marker, width, n, strings.TrimRight(lines[n-1], "\r"))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// IsApplicable проверяет, применимо ли правило к файлу, чтобы не запускать проверку заведомо без результата.
+// Правила без метода Applicable применимы к любому файлу.
+func IsApplicable(rule Rule, ctx *Context) bool {
+	applicable, ok := rule.(interface{ Applicable(*Context) bool })
+	return !ok || applicable.Applicable(ctx)
+}
+
+// AddedSince возвращает правила, появившиеся в указанной версии или позже.
+// Правила без метаданных о версии не отбираются.
+func AddedSince(rules []Rule, version string) []Rule {
+	var selected []Rule
+	for _, rule := range rules {
+		versioned, ok := rule.(interface{ AddedIn() string })
+		if !ok || versioned.AddedIn() == "" {
+			continue
+		}
+		if CompareVersions(versioned.AddedIn(), version) >= 0 {
+			selected = append(selected, rule)
+		}
+	}
+	return selected
+}
+
+// CompareVersions сравнивает версии вида 1.2.3 (допускается префикс v).
+// Возвращает -1, 0 или 1.
+func CompareVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numB, _ = strconv.Atoi(partsB[i])
+		}
+
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		}
 	}
+	return 0
 }
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// minSessionIDBytes минимальное количество случайных байтов в идентификаторе сессии
+const minSessionIDBytes = 16
+
+// WeakSessionIDRule проверяет генерацию идентификаторов сессий на достаточную энтропию
+type WeakSessionIDRule struct {
+	BaseRule
+	// Регулярное выражение для функций, генерирующих идентификаторы сессий
+	sessionFuncRegex *regexp.Regexp
+	// Функции, создающие UUID на основе времени
+	timeBasedUUIDFuncs map[string]bool
+}
+
+// NewWeakSessionIDRule создает новое правило для проверки энтропии идентификаторов сессий
+func NewWeakSessionIDRule() *WeakSessionIDRule {
+	return &WeakSessionIDRule{
+		BaseRule: BaseRule{
+			id:          "SEC010",
+			description: "Предсказуемый идентификатор сессии",
+			severity:    report.SeverityHigh,
+			cwe:         "CWE-330",
+			addedIn:     "0.2.0",
+			remediation: "Генерируйте идентификаторы сессий через crypto/rand длиной не менее 128 бит",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Session_Management_Cheat_Sheet.html"},
+		},
+		// Sid и SID ищутся на границе слов в camelCase и snake_case, чтобы не совпадать с inside или consider
+		sessionFuncRegex: regexp.MustCompile(`(?i:session|token)|(^|_)(sid|Sid|SID)([A-Z_]|$)|[a-z](Sid|SID)([A-Z_]|$)`),
+		timeBasedUUIDFuncs: map[string]bool{
+			"NewV1":   true,
+			"NewUUID": true,
+			"NewV6":   true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *WeakSessionIDRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil || !r.sessionFuncRegex.MatchString(funcDecl.Name.Name) {
+			continue
+		}
+
+		issues = append(issues, r.checkSessionFunc(funcDecl, ctx)...)
+	}
+
+	return issues
+}
+
+// checkSessionFunc проверяет функцию, генерирующую идентификатор сессии
+func (r *WeakSessionIDRule) checkSessionFunc(funcDecl *ast.FuncDecl, ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Размеры срезов байтов, созданных через make([]byte, n)
+	byteSlices := make(map[string]int)
+
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					continue
+				}
+				if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+					if size, ok := byteSliceSize(rhs); ok {
+						byteSlices[ident.Name] = size
+					}
+				}
+			}
+
+		case *ast.CallExpr:
+			sel, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			x, ok := sel.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			// UUID на основе времени: uuid.NewV1(), uuid.NewUUID()
+			if x.Name == "uuid" && r.timeBasedUUIDFuncs[sel.Sel.Name] {
+				issues = append(issues, r.NewIssue(node.Pos(), ctx,
+					"Идентификатор сессии на основе UUID "+sel.Sel.Name+" зависит от времени и предсказуем, используйте uuid.New (v4) или crypto/rand"))
+			}
+
+			// Заполнение короткого среза случайными байтами
+			var target ast.Expr
+			switch {
+			case x.Name == "rand" && sel.Sel.Name == "Read" && len(node.Args) == 1:
+				target = node.Args[0]
+			case x.Name == "io" && sel.Sel.Name == "ReadFull" && len(node.Args) == 2:
+				target = node.Args[1]
+			}
+			if ident, ok := target.(*ast.Ident); ok {
+				if size, ok := byteSlices[ident.Name]; ok && size < minSessionIDBytes {
+					issues = append(issues, r.NewIssue(node.Pos(), ctx,
+						"Идентификатор сессии содержит только "+strconv.Itoa(size)+" случайных байтов, используйте не менее "+strconv.Itoa(minSessionIDBytes)))
+				}
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// byteSliceSize возвращает размер среза из выражения make([]byte, n) с литеральным n
+func byteSliceSize(expr ast.Expr) (int, bool) {
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok || len(callExpr.Args) < 2 {
+		return 0, false
+	}
+	if fn, ok := callExpr.Fun.(*ast.Ident); !ok || fn.Name != "make" {
+		return 0, false
+	}
+	arrayType, ok := callExpr.Args[0].(*ast.ArrayType)
+	if !ok {
+		return 0, false
+	}
+	if elt, ok := arrayType.Elt.(*ast.Ident); !ok || elt.Name != "byte" {
+		return 0, false
+	}
+
+	lit, ok := callExpr.Args[1].(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, false
+	}
+	size, err := strconv.ParseInt(strings.ReplaceAll(lit.Value, "_", ""), 0, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int(size), true
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"go/ast"
+	"path"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// ShellExecRule проверяет запуск команд через командную оболочку: exec.Command("sh", "-c", cmd).
+// Оболочка интерпретирует строку команды, поэтому любые подстановки в нее могут изменить выполняемую команду.
+type ShellExecRule struct {
+	BaseRule
+	// Функции os/exec, запускающие команду, и индекс аргумента с именем программы
+	commandFuncs map[string]int
+	// Командные оболочки и флаг выполнения строки команды
+	shells map[string]string
+}
+
+// NewShellExecRule создает новое правило для проверки запуска команд через командную оболочку
+func NewShellExecRule() *ShellExecRule {
+	return &ShellExecRule{
+		BaseRule: BaseRule{
+			id:              "SEC026",
+			description:     "Запуск команды через командную оболочку",
+			severity:        report.SeverityLow,
+			cwe:             "CWE-78",
+			addedIn:         "0.2.0",
+			remediation:     "Передавайте программу и ее аргументы в exec.Command напрямую, без командной оболочки",
+			references:      []string{"https://cheatsheetseries.owasp.org/cheatsheets/OS_Command_Injection_Defense_Cheat_Sheet.html"},
+			requiredImports: []string{"os/exec"},
+		},
+		commandFuncs: map[string]int{
+			"Command":        0,
+			"CommandContext": 1,
+		},
+		shells: map[string]string{
+			"sh":      "-c",
+			"bash":    "-c",
+			"zsh":     "-c",
+			"dash":    "-c",
+			"ksh":     "-c",
+			"cmd":     "/c",
+			"cmd.exe": "/c",
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *ShellExecRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальное имя пакета os/exec с учетом псевдонима импорта
+	execName := importName(ctx, map[string]bool{"os/exec": true})
+	if execName == "" {
+		return issues
+	}
+
+	tracker := NewTaintTracker(ctx)
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		tainted := tracker.Propagate(funcDecl.Body)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			nameIndex, ok := r.commandFuncs[sel.Sel.Name]
+			if !ok || !isPackageCall(sel, execName, sel.Sel.Name) || nameIndex+1 >= len(call.Args) {
+				return true
+			}
+
+			shell, flag, ok := r.shellInvocation(ctx, call.Args[nameIndex], call.Args[nameIndex+1])
+			if !ok {
+				return true
+			}
+
+			// Строка команды и аргументы оболочки следуют за флагом
+			for _, arg := range call.Args[nameIndex+2:] {
+				if tainted.Derives(arg) {
+					issue := r.NewIssueRange(call.Pos(), call.End(), ctx,
+						"Команда для "+shell+" "+flag+" формируется из пользовательского ввода, что позволяет внедрить команды оболочки; "+
+							"передавайте программу и ее аргументы в exec."+sel.Sel.Name+" напрямую")
+					issue.Severity = report.SeverityHigh
+					issues = append(issues, issue)
+					return true
+				}
+			}
+
+			issues = append(issues, r.NewIssueRange(call.Pos(), call.End(), ctx,
+				"Команда выполняется через оболочку "+shell+" "+flag+"; "+
+					"передавайте программу и ее аргументы в exec."+sel.Sel.Name+" напрямую, без интерпретации оболочкой"))
+			return true
+		})
+	}
+
+	return issues
+}
+
+// shellInvocation проверяет, запускает ли вызов командную оболочку с флагом выполнения строки команды.
+// Имя оболочки может быть указано полным путем, например /bin/sh; флаг cmd /c не зависит от регистра.
+func (r *ShellExecRule) shellInvocation(ctx *Context, nameArg, flagArg ast.Expr) (shell, flag string, ok bool) {
+	name, ok := stringConstValue(ctx, nameArg)
+	if !ok {
+		return "", "", false
+	}
+	flag, ok = stringConstValue(ctx, flagArg)
+	if !ok {
+		return "", "", false
+	}
+
+	// Пути Windows приводятся к виду с прямыми разделителями
+	shell = strings.ToLower(path.Base(strings.ReplaceAll(name, `\`, "/")))
+	expected, ok := r.shells[shell]
+	if !ok || !strings.EqualFold(flag, expected) {
+		return "", "", false
+	}
+	return shell, flag, true
+}
@@ -2,9 +2,11 @@ package rules
 
 import (
 	"go/ast"
+	"go/constant"
 	"go/token"
-	_ "go/types"
+	"go/types"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"go-audit/pkg/report"
@@ -24,6 +26,10 @@ func NewSQLInjectionRule() *SQLInjectionRule {
 			id:          "SEC001",
 			description: "Потенциальная SQL-инъекция обнаружена",
 			severity:    report.SeverityCritical,
+			cwe:         "CWE-89",
+			addedIn:     "0.1.0",
+			remediation: "Используйте параметризованные запросы с плейсхолдерами (db.Query(\"... WHERE id = ?\", id)) вместо конкатенации и fmt.Sprintf",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/SQL_Injection_Prevention_Cheat_Sheet.html"},
 		},
 		sqlQueryRegex: regexp.MustCompile(`(?i)(SELECT|INSERT|UPDATE|DELETE|DROP|CREATE|ALTER|TRUNCATE)\s+`),
 	}
@@ -33,19 +39,44 @@ func NewSQLInjectionRule() *SQLInjectionRule {
 func (r *SQLInjectionRule) Check(ctx *Context) []report.Issue {
 	var issues []report.Issue
 
+	// Состояние переменных запросов текущей функции
+	queries := newQueryVars()
+	// Аргументы вызовов, о которых уже сообщено: конкатенация внутри них не дублируется
+	var reportedArgs []ast.Expr
+	// Конкатенации, о которых уже сообщено: несколько SQL-литералов в одном выражении дают одну проблему
+	reportedConcats := make(map[ast.Node]bool)
+
 	// Находим все вызовы функций, которые могут содержать SQL
 	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		if funcDecl, ok := n.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+			queries = r.collectQueryVars(funcDecl.Body)
+		}
+
 		// Проверяем вызовы методов, таких как db.Query, db.Exec и т.д.
 		if callExpr, ok := n.(*ast.CallExpr); ok {
 			if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 				methodName := selExpr.Sel.Name
 
 				// Методы, которые могут быть уязвимы к SQL-инъекциям
-				if isVulnerableSQLMethod(methodName) && len(callExpr.Args) > 0 {
+				if isVulnerableSQLMethod(methodName) && isSQLReceiver(ctx, selExpr) && len(callExpr.Args) > 0 {
 					// Проверяем первый аргумент, который должен быть SQL-запросом
-					if isRiskySQLQuery(callExpr.Args[0], r.sqlQueryRegex) {
-						issues = append(issues, r.NewIssue(callExpr.Pos(), ctx,
+					query := callExpr.Args[0]
+					if name, ok := queries.builderString(query); ok {
+						// Запрос собран в strings.Builder или bytes.Buffer: db.Query(sb.String())
+						if queries.builders[name] {
+							issues = append(issues, r.NewIssueRange(callExpr.Pos(), callExpr.End(), ctx,
+								"Возможная SQL-инъекция: запрос собран в "+name+" из непроверенных данных, используйте подготовленные запросы с параметрами"))
+						}
+					} else if ident, ok := query.(*ast.Ident); ok && queries.tainted[ident.Name] {
+						// Конкатенация с SQL-литералом уже отмечена в месте формирования запроса
+						if !queries.reported[ident.Name] {
+							issues = append(issues, r.NewIssueRange(callExpr.Pos(), callExpr.End(), ctx,
+								"Возможная SQL-инъекция: запрос "+ident.Name+" сформирован из непроверенных данных, используйте подготовленные запросы с параметрами"))
+						}
+					} else if isRiskySQLQuery(query, queries.safe) {
+						issues = append(issues, r.NewIssueRange(callExpr.Pos(), callExpr.End(), ctx,
 							"Возможная SQL-инъекция: используйте подготовленные запросы с параметрами"))
+						reportedArgs = append(reportedArgs, query)
 					}
 				}
 			}
@@ -53,12 +84,17 @@ func (r *SQLInjectionRule) Check(ctx *Context) []report.Issue {
 
 		// Также проверяем строковые литералы на наличие SQL-запросов
 		if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING {
-			if r.sqlQueryRegex.MatchString(lit.Value) {
+			if r.sqlQueryRegex.MatchString(lit.Value) && !withinAny(lit, reportedArgs) {
 				// Проверяем, не используются ли строковые конкатенации в родительском выражении
-				if parent, ok := getParent(ctx.File, lit); ok {
+				if parent, ok := ctx.Parent(lit); ok {
 					if binExpr, ok := parent.(*ast.BinaryExpr); ok && binExpr.Op == token.ADD {
-						issues = append(issues, r.NewIssue(lit.Pos(), ctx,
-							"Использование конкатенации строк в SQL-запросе может привести к SQL-инъекции"))
+						// Позиция указывает на начало всего выражения конкатенации, а не на литерал
+						concat := outermostConcatenation(ctx, binExpr)
+						if !reportedConcats[concat] {
+							reportedConcats[concat] = true
+							issues = append(issues, r.NewIssueRange(concat.Pos(), concat.End(), ctx,
+								"Использование конкатенации строк в SQL-запросе может привести к SQL-инъекции"))
+						}
 					}
 				}
 			}
@@ -67,9 +103,454 @@ func (r *SQLInjectionRule) Check(ctx *Context) []report.Issue {
 		return true
 	})
 
+	return append(issues, r.checkTemplates(ctx)...)
+}
+
+// checkTemplates находит SQL-запросы, сформированные через text/template из пользовательского ввода:
+// шаблон подставляет значения без экранирования. Учитываются только шаблоны, текст которых похож на SQL.
+func (r *SQLInjectionRule) checkTemplates(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	templateName := importName(ctx, map[string]bool{"text/template": true})
+	if templateName == "" {
+		return issues
+	}
+
+	tracker := NewTaintTracker(ctx)
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		tainted := tracker.Propagate(funcDecl.Body)
+		sqlTemplates := r.collectSQLTemplates(ctx, funcDecl.Body, templateName)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			// tmpl.Execute(w, data) или tmpl.ExecuteTemplate(w, name, data)
+			var data ast.Expr
+			switch {
+			case sel.Sel.Name == "Execute" && len(call.Args) == 2:
+				data = call.Args[1]
+			case sel.Sel.Name == "ExecuteTemplate" && len(call.Args) == 3:
+				data = call.Args[2]
+			default:
+				return true
+			}
+
+			isSQL := r.isSQLTemplate(ctx, sel.X, templateName)
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				isSQL = sqlTemplates[ident.Name]
+			}
+			if !isSQL || !tainted.Derives(data) {
+				return true
+			}
+
+			issue := r.NewIssueRange(call.Pos(), call.End(), ctx,
+				"Возможная SQL-инъекция: запрос формируется через text/template из пользовательского ввода без экранирования, "+
+					"используйте подготовленные запросы с параметрами")
+			issue.Severity = report.SeverityHigh
+			issues = append(issues, issue)
+			return true
+		})
+	}
+
 	return issues
 }
 
+// collectSQLTemplates находит переменные с шаблонами text/template, текст которых похож на SQL:
+// tmpl := template.Must(template.New("q").Parse("SELECT ..."))
+func (r *SQLInjectionRule) collectSQLTemplates(ctx *Context, body *ast.BlockStmt, templateName string) map[string]bool {
+	templates := make(map[string]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+			return true
+		}
+		// Parse возвращает шаблон и ошибку: tmpl, err := template.New("q").Parse(query)
+		if ident, ok := assign.Lhs[0].(*ast.Ident); ok && r.isSQLTemplate(ctx, assign.Rhs[0], templateName) {
+			templates[ident.Name] = true
+		}
+		return true
+	})
+
+	return templates
+}
+
+// isSQLTemplate проверяет, создает ли выражение шаблон пакета text/template из текста, похожего на SQL
+func (r *SQLInjectionRule) isSQLTemplate(ctx *Context, expr ast.Expr, templateName string) bool {
+	fromPackage, isSQL := false, false
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if isPackageCall(sel, templateName, sel.Sel.Name) {
+			fromPackage = true
+		}
+		if sel.Sel.Name == "Parse" && len(call.Args) == 1 {
+			if text, ok := stringConstValue(ctx, call.Args[0]); ok && r.sqlQueryRegex.MatchString(text) {
+				isSQL = true
+			}
+		}
+		return true
+	})
+
+	return fromPackage && isSQL
+}
+
+// stringConstValue возвращает значение строкового литерала или константы, известной проверке типов
+func stringConstValue(ctx *Context, expr ast.Expr) (string, bool) {
+	if ctx.TypesInfo != nil {
+		if tv, ok := ctx.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value), true
+		}
+	}
+
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+// queryVars состояние переменных, которые могут использоваться как SQL-запросы, в пределах функции
+type queryVars struct {
+	// Переменные, сформированные конкатенацией или fmt.Sprintf с подстановкой строк
+	tainted map[string]bool
+	// Переменные, содержащие конкатенацию с SQL-литералом, о которой сообщается в месте формирования
+	reported map[string]bool
+	// Переменные, сформированные только из литералов или безопасных шаблонов
+	safe map[string]bool
+	// Переменные strings.Builder и bytes.Buffer: true, если в них записаны непроверенные данные
+	builders map[string]bool
+}
+
+// newQueryVars создает пустое состояние переменных запросов
+func newQueryVars() *queryVars {
+	return &queryVars{
+		tainted:  make(map[string]bool),
+		reported: make(map[string]bool),
+		safe:     make(map[string]bool),
+		builders: make(map[string]bool),
+	}
+}
+
+// builderString проверяет, является ли выражение вызовом String() у отслеживаемого strings.Builder
+// или bytes.Buffer, и возвращает имя переменной
+func (q *queryVars) builderString(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return "", false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "String" {
+		return "", false
+	}
+	return q.builderName(sel.X)
+}
+
+// safeBuilderString проверяет, является ли выражение вызовом String() у strings.Builder,
+// в который записаны только литералы
+func (q *queryVars) safeBuilderString(expr ast.Expr) bool {
+	name, ok := q.builderString(expr)
+	return ok && !q.builders[name]
+}
+
+// builderName возвращает имя отслеживаемого strings.Builder или bytes.Buffer: sb или &sb
+func (q *queryVars) builderName(expr ast.Expr) (string, bool) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	_, ok = q.builders[ident.Name]
+	return ident.Name, ok
+}
+
+// collectQueryVars отслеживает присваивания в теле функции в порядке следования:
+// q := fmt.Sprintf("... '%s'", name) делает q опасным, q := "SELECT ... $1" - безопасным
+func (r *SQLInjectionRule) collectQueryVars(body *ast.BlockStmt) *queryVars {
+	queries := newQueryVars()
+
+	assign := func(name string, value ast.Expr, appendTo bool) {
+		switch {
+		case r.isTaintedQuery(value, queries):
+			queries.tainted[name] = true
+			queries.reported[name] = r.hasSQLConcatenation(value, queries)
+			queries.safe[name] = false
+		case appendTo:
+			// q += " LIMIT 10" сохраняет текущее состояние
+		case isSafeQuery(value, queries.safe) || queries.safeBuilderString(value):
+			queries.safe[name] = true
+			queries.tainted[name] = false
+			queries.reported[name] = false
+		default:
+			// Значение неизвестного происхождения
+			queries.safe[name] = false
+			queries.tainted[name] = false
+			queries.reported[name] = false
+		}
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, rhs := range node.Rhs {
+				ident, ok := node.Lhs[i].(*ast.Ident)
+				if !ok || ident.Name == "_" {
+					continue
+				}
+				// sb := &strings.Builder{}, buf := bytes.NewBufferString(prefix)
+				if tainted, ok := builderConstructor(rhs, queries.safe); ok && node.Tok != token.ADD_ASSIGN {
+					queries.builders[ident.Name] = tainted
+				}
+				switch node.Tok {
+				case token.ADD_ASSIGN:
+					// q += "' AND name = '" + name
+					value := &ast.BinaryExpr{X: ident, Op: token.ADD, Y: rhs}
+					assign(ident.Name, value, true)
+				case token.ASSIGN, token.DEFINE:
+					assign(ident.Name, rhs, false)
+				}
+			}
+		case *ast.ValueSpec:
+			// var sb strings.Builder
+			if node.Type != nil && isBuilderType(node.Type) {
+				for _, name := range node.Names {
+					queries.builders[name.Name] = false
+				}
+			}
+			for i, value := range node.Values {
+				if i < len(node.Names) {
+					assign(node.Names[i].Name, value, false)
+				}
+			}
+		case *ast.CallExpr:
+			// sb.WriteString(name), fmt.Fprintf(&sb, "... '%s'", name)
+			if name, ok := builderWrite(node, queries); ok {
+				queries.builders[name] = true
+			}
+		}
+		return true
+	})
+
+	return queries
+}
+
+// isBuilderType проверяет, является ли тип strings.Builder или bytes.Buffer, в том числе указателем на них
+func isBuilderType(expr ast.Expr) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	name := astToString(expr)
+	return name == "strings.Builder" || name == "bytes.Buffer"
+}
+
+// builderConstructor проверяет, создает ли выражение strings.Builder или bytes.Buffer,
+// и определяет, содержит ли он непроверенные данные с момента создания
+func builderConstructor(expr ast.Expr, safe map[string]bool) (bool, bool) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		expr = unary.X
+	}
+
+	switch node := expr.(type) {
+	case *ast.CompositeLit:
+		// strings.Builder{}
+		return false, node.Type != nil && isBuilderType(node.Type)
+	case *ast.CallExpr:
+		// new(strings.Builder)
+		if ident, ok := node.Fun.(*ast.Ident); ok && ident.Name == "new" && len(node.Args) == 1 {
+			return false, isBuilderType(node.Args[0])
+		}
+		// bytes.NewBufferString(prefix), bytes.NewBuffer(data)
+		name := astToString(node.Fun)
+		if (name == "bytes.NewBufferString" || name == "bytes.NewBuffer") && len(node.Args) == 1 {
+			return !isSafeBuilderInput(node.Args[0], safe), true
+		}
+	}
+	return false, false
+}
+
+// builderWrite проверяет, записывает ли вызов непроверенные данные в отслеживаемый strings.Builder
+// или bytes.Buffer, и возвращает имя переменной
+func builderWrite(call *ast.CallExpr, queries *queryVars) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	switch sel.Sel.Name {
+	case "WriteString", "WriteByte", "WriteRune", "Write":
+		name, ok := queries.builderName(sel.X)
+		if !ok || len(call.Args) != 1 {
+			return "", false
+		}
+		return name, !isSafeBuilderInput(call.Args[0], queries.safe)
+
+	case "Fprintf", "Fprint", "Fprintln":
+		if len(call.Args) < 2 {
+			return "", false
+		}
+		name, ok := queries.builderName(call.Args[0])
+		if !ok {
+			return "", false
+		}
+		if sel.Sel.Name == "Fprintf" {
+			// fmt.Fprintf(&sb, format, args...) проверяется так же, как fmt.Sprintf(format, args...)
+			sprintf := &ast.CallExpr{Fun: &ast.SelectorExpr{X: sel.X, Sel: ast.NewIdent("Sprintf")}, Args: call.Args[1:]}
+			return name, isRiskySQLQuery(sprintf, queries.safe)
+		}
+		for _, arg := range call.Args[1:] {
+			if !isSafeBuilderInput(arg, queries.safe) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// isSafeBuilderInput проверяет, что в strings.Builder записывается литерал или безопасная переменная:
+// sb.WriteString(" WHERE id = $1"), sb.WriteByte(' '), buf.Write([]byte("SELECT"))
+func isSafeBuilderInput(expr ast.Expr, safe map[string]bool) bool {
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		return lit.Kind == token.STRING || lit.Kind == token.CHAR || lit.Kind == token.INT
+	}
+	// Преобразование []byte("...")
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 1 {
+		if array, ok := call.Fun.(*ast.ArrayType); ok && array.Len == nil && astToString(array.Elt) == "byte" {
+			return isSafeBuilderInput(call.Args[0], safe)
+		}
+	}
+	return isSafeQuery(expr, safe)
+}
+
+// isTaintedQuery проверяет, формируется ли значение конкатенацией, fmt.Sprintf
+// с подстановкой строк или из уже опасной переменной
+func (r *SQLInjectionRule) isTaintedQuery(expr ast.Expr, queries *queryVars) bool {
+	switch node := expr.(type) {
+	case *ast.Ident:
+		return queries.tainted[node.Name]
+	case *ast.ParenExpr:
+		return r.isTaintedQuery(node.X, queries)
+	case *ast.BinaryExpr:
+		if node.Op != token.ADD {
+			return false
+		}
+		return r.isTaintedQuery(node.X, queries) || r.isTaintedQuery(node.Y, queries) ||
+			!isSafeQuery(node.X, queries.safe) || !isSafeQuery(node.Y, queries.safe)
+	case *ast.CallExpr:
+		if name, ok := queries.builderString(node); ok {
+			return queries.builders[name]
+		}
+		if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Sprintf" {
+			return isRiskySQLQuery(node, queries.safe)
+		}
+	}
+	return false
+}
+
+// hasSQLConcatenation проверяет, содержит ли выражение конкатенацию с SQL-литералом
+// или переменную, о конкатенации в которой уже сообщено
+func (r *SQLInjectionRule) hasSQLConcatenation(expr ast.Expr, queries *queryVars) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Ident:
+			if queries.reported[node.Name] {
+				found = true
+			}
+		case *ast.BinaryExpr:
+			if node.Op != token.ADD {
+				return true
+			}
+			for _, operand := range []ast.Expr{node.X, node.Y} {
+				if lit, ok := operand.(*ast.BasicLit); ok && lit.Kind == token.STRING && r.sqlQueryRegex.MatchString(lit.Value) {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isSafeQuery проверяет, что выражение составлено только из строковых литералов
+// и переменных, сформированных из литералов
+func isSafeQuery(expr ast.Expr, safe map[string]bool) bool {
+	switch node := expr.(type) {
+	case *ast.BasicLit:
+		return node.Kind == token.STRING
+	case *ast.Ident:
+		return safe[node.Name]
+	case *ast.ParenExpr:
+		return isSafeQuery(node.X, safe)
+	case *ast.BinaryExpr:
+		return node.Op == token.ADD && isSafeQuery(node.X, safe) && isSafeQuery(node.Y, safe)
+	case *ast.CallExpr:
+		// fmt.Sprintf без подстановки строк: fmt.Sprintf("... LIMIT %d", limit)
+		if sel, ok := node.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Sprintf" {
+			return !isRiskySQLQuery(node, safe)
+		}
+	}
+	return false
+}
+
+// outermostConcatenation возвращает внешнее выражение цепочки конкатенаций,
+// в которую входит binExpr: для "SELECT " + a + " WHERE" это все выражение целиком
+func outermostConcatenation(ctx *Context, binExpr *ast.BinaryExpr) ast.Expr {
+	var result ast.Expr = binExpr
+	for {
+		parent, ok := ctx.Parent(result)
+		if !ok {
+			return result
+		}
+		switch node := parent.(type) {
+		case *ast.BinaryExpr:
+			if node.Op != token.ADD {
+				return result
+			}
+			result = node
+		case *ast.ParenExpr:
+			result = node
+		default:
+			return result
+		}
+	}
+}
+
+// withinAny проверяет, находится ли узел внутри одного из выражений
+func withinAny(node ast.Node, exprs []ast.Expr) bool {
+	for _, expr := range exprs {
+		if node.Pos() >= expr.Pos() && node.End() <= expr.End() {
+			return true
+		}
+	}
+	return false
+}
+
 // isVulnerableSQLMethod проверяет, является ли метод уязвимым к SQL-инъекциям
 func isVulnerableSQLMethod(methodName string) bool {
 	vulnerableMethods := map[string]bool{
@@ -85,8 +566,77 @@ func isVulnerableSQLMethod(methodName string) bool {
 	return vulnerableMethods[methodName]
 }
 
-// isRiskySQLQuery проверяет, является ли аргумент рискованным SQL-запросом
-func isRiskySQLQuery(arg ast.Expr, sqlRegex *regexp.Regexp) bool {
+// sqlReceiverTypes типы database/sql, методы которых принимают SQL-запрос
+var sqlReceiverTypes = map[string]bool{
+	"DB":   true,
+	"Tx":   true,
+	"Stmt": true,
+	"Conn": true,
+}
+
+// isSQLReceiver проверяет по информации о типах, что метод вызывается у типа из database/sql.
+// Если тип получателя не удалось определить, возвращает true (сопоставление только по имени).
+func isSQLReceiver(ctx *Context, sel *ast.SelectorExpr) bool {
+	if ctx.TypesInfo == nil {
+		return true
+	}
+
+	selection, ok := ctx.TypesInfo.Selections[sel]
+	if !ok {
+		// Функция пакета, а не метод: db.Query не может быть квалифицированным идентификатором
+		if obj, ok := ctx.TypesInfo.Uses[sel.Sel]; ok && obj.Pkg() != nil {
+			return false
+		}
+		return true
+	}
+
+	recv := selection.Recv()
+	if recv == nil {
+		return true
+	}
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	switch t := recv.(type) {
+	case *types.Named:
+		if _, ok := t.Underlying().(*types.Interface); ok {
+			// Интерфейс-обертка: проверяем, возвращает ли метод типы database/sql
+			return returnsSQLType(selection.Type())
+		}
+		obj := t.Obj()
+		return obj.Pkg() != nil && obj.Pkg().Path() == "database/sql" && sqlReceiverTypes[obj.Name()]
+	case *types.Interface:
+		return returnsSQLType(selection.Type())
+	case *types.Basic:
+		// Неразрешенный тип
+		return t.Kind() == types.Invalid
+	}
+	return false
+}
+
+// returnsSQLType проверяет, возвращает ли сигнатура метода тип из database/sql
+func returnsSQLType(t types.Type) bool {
+	sig, ok := t.(*types.Signature)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < sig.Results().Len(); i++ {
+		result := sig.Results().At(i).Type()
+		if ptr, ok := result.(*types.Pointer); ok {
+			result = ptr.Elem()
+		}
+		if named, ok := result.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "database/sql" {
+			return true
+		}
+	}
+	return false
+}
+
+// isRiskySQLQuery проверяет, является ли аргумент рискованным SQL-запросом.
+// safe содержит переменные, сформированные только из литералов.
+func isRiskySQLQuery(arg ast.Expr, safe map[string]bool) bool {
 	switch expr := arg.(type) {
 	case *ast.BasicLit:
 		// Если это строковый литерал
@@ -99,8 +649,8 @@ func isRiskySQLQuery(arg ast.Expr, sqlRegex *regexp.Regexp) bool {
 			return true
 		}
 	case *ast.Ident:
-		// Использование простых переменных может быть опасным
-		return true
+		// Использование переменных неизвестного происхождения может быть опасным
+		return !safe[expr.Name]
 	case *ast.CallExpr:
 		// Безопасными считаются вызовы функций типа fmt.Sprintf,
 		// но только если они используют placeholder-ы (%d, %s) без прямой подстановки
@@ -125,47 +675,3 @@ func isRiskySQLQuery(arg ast.Expr, sqlRegex *regexp.Regexp) bool {
 	}
 	return false
 }
-
-// getParent находит родительский узел для данного узла в AST
-func getParent(file *ast.File, node ast.Node) (ast.Node, bool) {
-	var parent ast.Node
-	var found bool
-
-	ast.Inspect(file, func(n ast.Node) bool {
-		if found || n == nil {
-			return false
-		}
-
-		// Проверяем все дочерние узлы текущего узла
-		children := childNodes(n)
-		for _, child := range children {
-			if child == node {
-				parent = n
-				found = true
-				return false
-			}
-		}
-		return true
-	})
-
-	return parent, found
-}
-
-// childNodes возвращает все дочерние узлы для данного узла AST
-func childNodes(n ast.Node) []ast.Node {
-	if n == nil {
-		return nil
-	}
-
-	var children []ast.Node
-
-	ast.Inspect(n, func(node ast.Node) bool {
-		if node != n && node != nil {
-			children = append(children, node)
-			return false
-		}
-		return true
-	})
-
-	return children
-}
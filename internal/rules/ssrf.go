@@ -0,0 +1,194 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// SSRFRule проверяет запросы net/http по адресу из пользовательского ввода (server-side request forgery)
+type SSRFRule struct {
+	BaseRule
+	// Функции net/http, выполняющие или создающие запрос, и индекс аргумента с адресом
+	requestFuncs map[string]int
+}
+
+// NewSSRFRule создает новое правило для проверки подделки серверных запросов
+func NewSSRFRule() *SSRFRule {
+	return &SSRFRule{
+		BaseRule: BaseRule{
+			id:          "SEC020",
+			description: "Запрос по адресу из пользовательского ввода (SSRF)",
+			severity:    report.SeverityHigh,
+			cwe:         "CWE-918",
+			addedIn:     "0.2.0",
+			remediation: "Проверяйте хост запроса по списку разрешенных и запрещайте обращения к внутренним адресам",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Server_Side_Request_Forgery_Prevention_Cheat_Sheet.html"},
+		},
+		requestFuncs: map[string]int{
+			"Get":                   0,
+			"Head":                  0,
+			"Post":                  0,
+			"PostForm":              0,
+			"NewRequest":            1,
+			"NewRequestWithContext": 2,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *SSRFRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Локальное имя net/http с учетом псевдонима импорта
+	httpName := importName(ctx, map[string]bool{"net/http": true})
+	if httpName == "" {
+		return issues
+	}
+
+	tracker := NewTaintTracker(ctx)
+
+	for _, decl := range ctx.File.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+
+		tainted := tracker.Propagate(funcDecl.Body)
+		validated := collectAllowlistChecks(funcDecl.Body)
+
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			argIndex, ok := r.requestFuncs[sel.Sel.Name]
+			if !ok || !isPackageCall(sel, httpName, sel.Sel.Name) || argIndex >= len(call.Args) {
+				return true
+			}
+
+			target := call.Args[argIndex]
+			if !tainted.Derives(target) || isAllowlistedTarget(target, validated) {
+				return true
+			}
+
+			issues = append(issues, r.NewIssue(call.Pos(), ctx,
+				"Потенциальная SSRF: адрес запроса в http."+sel.Sel.Name+" формируется из пользовательского ввода, "+
+					"проверяйте хост по списку разрешенных"))
+			return true
+		})
+	}
+
+	return issues
+}
+
+// collectAllowlistChecks находит переменные с адресом, проверенным по списку разрешенных хостов:
+// allowedHosts[u.Host], u.Hostname() == "api.example.com" или вызов функции с allow в имени, например isAllowedURL(target).
+// Проверка разобранного адреса u, err := url.Parse(target) распространяется на исходную переменную.
+func collectAllowlistChecks(body *ast.BlockStmt) map[string]bool {
+	validated := make(map[string]bool)
+	// Разобранные адреса и исходные переменные: u, err := url.Parse(target)
+	parsed := make(map[string]string)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+			return true
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || astToString(call.Fun) != "url.Parse" || len(call.Args) != 1 {
+			return true
+		}
+		u, ok := assign.Lhs[0].(*ast.Ident)
+		source, ok2 := call.Args[0].(*ast.Ident)
+		if ok && ok2 {
+			parsed[u.Name] = source.Name
+		}
+		return true
+	})
+
+	// markChecked отмечает переменные выражения и исходные переменные разобранных адресов
+	markChecked := func(expr ast.Expr) {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok {
+				validated[ident.Name] = true
+				if source, ok := parsed[ident.Name]; ok {
+					validated[source] = true
+				}
+			}
+			return true
+		})
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.IndexExpr:
+			// allowedHosts[u.Host] или allowedURLs[target]
+			if isHostSelector(node.Index) || isAllowlistName(node.X) {
+				markChecked(node.Index)
+			}
+
+		case *ast.BinaryExpr:
+			// u.Host == "api.example.com"
+			if node.Op == token.EQL || node.Op == token.NEQ {
+				if isHostSelector(node.X) {
+					markChecked(node.X)
+				}
+				if isHostSelector(node.Y) {
+					markChecked(node.Y)
+				}
+			}
+
+		case *ast.CallExpr:
+			// isAllowedURL(target) или allowlist.Contains(u.Hostname())
+			if isAllowlistName(node.Fun) {
+				for _, arg := range node.Args {
+					markChecked(arg)
+				}
+			}
+		}
+		return true
+	})
+
+	return validated
+}
+
+// isAllowlistedTarget проверяет, является ли адрес проверенной переменной или производным от нее: target, u.String()
+func isAllowlistedTarget(expr ast.Expr, validated map[string]bool) bool {
+	for {
+		switch node := expr.(type) {
+		case *ast.Ident:
+			return validated[node.Name]
+		case *ast.CallExpr:
+			if len(node.Args) != 0 {
+				return false
+			}
+			expr = node.Fun
+		case *ast.SelectorExpr:
+			expr = node.X
+		default:
+			return false
+		}
+	}
+}
+
+// isHostSelector проверяет, является ли выражение хостом разобранного адреса: u.Host или u.Hostname()
+func isHostSelector(expr ast.Expr) bool {
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) == 0 {
+		expr = call.Fun
+	}
+	sel, ok := expr.(*ast.SelectorExpr)
+	return ok && (sel.Sel.Name == "Host" || sel.Sel.Name == "Hostname")
+}
+
+// isAllowlistName проверяет, указывает ли имя выражения на список разрешенных значений: allowedHosts, isAllowed
+func isAllowlistName(expr ast.Expr) bool {
+	name := strings.ToLower(astToString(expr))
+	return strings.Contains(name, "allow") || strings.Contains(name, "whitelist")
+}
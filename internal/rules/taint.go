@@ -0,0 +1,205 @@
+package rules
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// defaultUserInputSources шаблоны выражений, возвращающих пользовательский ввод
+var defaultUserInputSources = []string{
+	"r.URL", "r.Form", "r.PostForm", "r.MultipartForm", "r.FormValue",
+	"r.PostFormValue", "r.QueryParam", "r.Query", "r.Param", "r.Body",
+	"json.Unmarshal", "json.Decode", "xml.Unmarshal", "xml.Decode",
+	"ioutil.ReadAll", "bufio.Scanner", "bufio.Reader",
+}
+
+// TaintTracker определяет, получено ли выражение из пользовательского ввода.
+// Отслеживаются переменные файла, которым присвоен источник ввода или результат функции пакета,
+// возвращающей пользовательский ввод.
+type TaintTracker struct {
+	// Шаблоны источников пользовательского ввода
+	sources []string
+	// Переменные, содержащие пользовательский ввод
+	vars map[string]bool
+}
+
+// NewTaintTracker создает трекер и собирает переменные файла с пользовательским вводом
+func NewTaintTracker(ctx *Context) *TaintTracker {
+	t := &TaintTracker{
+		sources: defaultUserInputSources,
+		vars:    make(map[string]bool),
+	}
+	t.collectVars(ctx)
+	return t
+}
+
+// IsSource проверяет, является ли выражение источником пользовательского ввода: r.URL или r.FormValue(...)
+func (t *TaintTracker) IsSource(expr ast.Expr) bool {
+	switch node := expr.(type) {
+	case *ast.SelectorExpr:
+		return t.matchesSource(astToString(node))
+	case *ast.CallExpr:
+		// Проверяем, является ли вызов функции источником пользовательского ввода
+		if sel, ok := node.Fun.(*ast.SelectorExpr); ok {
+			return t.matchesSource(astToString(sel))
+		}
+	}
+	return false
+}
+
+// IsTainted проверяет, содержит ли выражение пользовательский ввод: отслеживаемую переменную,
+// источник ввода, конкатенацию или аргумент вызова с ними
+func (t *TaintTracker) IsTainted(expr ast.Expr) bool {
+	switch node := expr.(type) {
+	case *ast.Ident:
+		// Проверяем, является ли идентификатор пользовательским вводом
+		return t.vars[node.Name]
+	case *ast.SelectorExpr:
+		// Проверяем, является ли селектор пользовательским вводом
+		return t.matchesSource(astToString(node))
+	case *ast.BinaryExpr:
+		// Проверяем, содержат ли части бинарного выражения пользовательский ввод
+		return t.IsTainted(node.X) || t.IsTainted(node.Y)
+	case *ast.CallExpr:
+		// Проверяем аргументы вызова функции
+		for _, arg := range node.Args {
+			if t.IsTainted(arg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Derives проверяет, содержит ли выражение пользовательский ввод на любом уровне вложенности,
+// например filepath.Clean(r.FormValue("file"))
+func (t *TaintTracker) Derives(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok && (t.IsSource(e) || t.IsTainted(e)) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// Propagate возвращает трекер для тела функции, в котором отслеживаются также переменные,
+// получившие значение выражения с пользовательским вводом: p := filepath.Join(base, name)
+func (t *TaintTracker) Propagate(body *ast.BlockStmt) *TaintTracker {
+	local := &TaintTracker{
+		sources: t.sources,
+		vars:    make(map[string]bool, len(t.vars)),
+	}
+	for name := range t.vars {
+		local.vars[name] = true
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			if i >= len(assign.Lhs) {
+				continue
+			}
+			if ident, ok := assign.Lhs[i].(*ast.Ident); ok && local.Derives(rhs) {
+				local.vars[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	return local
+}
+
+// matchesSource проверяет, соответствует ли строковое представление выражения одному из источников
+func (t *TaintTracker) matchesSource(exprStr string) bool {
+	for _, source := range t.sources {
+		if strings.Contains(exprStr, source) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectVars находит переменные, которым присваивается пользовательский ввод
+func (t *TaintTracker) collectVars(ctx *Context) {
+	inputFuncs := t.collectInputFuncs(ctx)
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			// Проверяем присваивания, где справа находится источник пользовательского ввода
+			for i, rhs := range node.Rhs {
+				if i >= len(node.Lhs) {
+					continue
+				}
+
+				if t.IsSource(rhs) || isUserInputFuncCall(rhs, inputFuncs) {
+					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
+						t.vars[ident.Name] = true
+					}
+				}
+			}
+
+		case *ast.ValueSpec:
+			// Проверяем объявления переменных
+			for i, val := range node.Values {
+				if i >= len(node.Names) {
+					continue
+				}
+
+				if t.IsSource(val) || isUserInputFuncCall(val, inputFuncs) {
+					t.vars[node.Names[i].Name] = true
+				}
+			}
+		}
+
+		return true
+	})
+}
+
+// collectInputFuncs находит функции пакета, возвращающие пользовательский ввод.
+// Просматриваются все файлы пакета, поэтому источник и использование могут находиться в разных файлах.
+func (t *TaintTracker) collectInputFuncs(ctx *Context) map[string]bool {
+	inputFuncs := make(map[string]bool)
+
+	for _, file := range ctx.Files() {
+		for _, decl := range file.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil || funcDecl.Body == nil {
+				continue
+			}
+
+			ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.FuncLit:
+					// Возвраты вложенных функций не относятся к объявленной функции
+					return false
+				case *ast.ReturnStmt:
+					for _, result := range node.Results {
+						if t.IsSource(result) {
+							inputFuncs[funcDecl.Name.Name] = true
+							return false
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	return inputFuncs
+}
+
+// isUserInputFuncCall проверяет, является ли выражение вызовом функции, возвращающей пользовательский ввод
+func isUserInputFuncCall(expr ast.Expr, inputFuncs map[string]bool) bool {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && inputFuncs[ident.Name]
+}
@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"go-audit/pkg/report"
+)
+
+// InsecureTempFileRule проверяет создание временных файлов и директорий в небезопасных расположениях
+type InsecureTempFileRule struct {
+	BaseRule
+	// Функции создания временных файлов и индекс аргумента с директорией
+	tempFuncs map[string]int
+	// Функции создания файлов и директорий по явному пути
+	pathFuncs map[string]bool
+}
+
+// NewInsecureTempFileRule создает новое правило для проверки временных файлов и директорий
+func NewInsecureTempFileRule() *InsecureTempFileRule {
+	return &InsecureTempFileRule{
+		BaseRule: BaseRule{
+			id:          "SEC012",
+			description: "Небезопасное создание временных файлов",
+			severity:    report.SeverityMedium,
+			cwe:         "CWE-377",
+			addedIn:     "0.2.0",
+			remediation: "Создавайте временные файлы и директории через os.CreateTemp и os.MkdirTemp вместо предсказуемых путей",
+		},
+		tempFuncs: map[string]int{
+			"os.MkdirTemp":    0,
+			"os.CreateTemp":   0,
+			"ioutil.TempDir":  0,
+			"ioutil.TempFile": 0,
+		},
+		pathFuncs: map[string]bool{
+			"os.Mkdir":         true,
+			"os.MkdirAll":      true,
+			"os.Create":        true,
+			"os.OpenFile":      true,
+			"os.WriteFile":     true,
+			"ioutil.WriteFile": true,
+		},
+	}
+}
+
+// Check реализует интерфейс Rule
+func (r *InsecureTempFileRule) Check(ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	userInput := NewTaintTracker(ctx)
+
+	ast.Inspect(ctx.File, func(n ast.Node) bool {
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		x, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		funcName := x.Name + "." + sel.Sel.Name
+
+		// os.MkdirTemp(userDir, "x")
+		if argIndex, ok := r.tempFuncs[funcName]; ok && argIndex < len(callExpr.Args) {
+			if userInput.IsTainted(callExpr.Args[argIndex]) {
+				issues = append(issues, r.NewIssue(callExpr.Pos(), ctx,
+					"Директория для "+funcName+" задается пользовательским вводом, используйте фиксированную директорию"))
+			}
+			return true
+		}
+
+		// os.Mkdir(os.TempDir()+"/fixed", 0755)
+		if r.pathFuncs[funcName] && len(callExpr.Args) > 0 && isHandRolledTempPath(callExpr.Args[0]) {
+			issues = append(issues, r.NewIssue(callExpr.Pos(), ctx,
+				"Предсказуемый путь во временной директории в "+funcName+", используйте os.MkdirTemp или os.CreateTemp"))
+		}
+
+		return true
+	})
+
+	return issues
+}
+
+// isHandRolledTempPath проверяет, строится ли путь из временной директории и фиксированного имени
+func isHandRolledTempPath(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CallExpr:
+			// os.TempDir()
+			if sel, ok := node.Fun.(*ast.SelectorExpr); ok && isPackageCall(sel, "os", "TempDir") {
+				found = true
+			}
+		case *ast.BasicLit:
+			// "/tmp/app"
+			if node.Kind == token.STRING && strings.HasPrefix(strings.Trim(node.Value, "`\""), "/tmp/") {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
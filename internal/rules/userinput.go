@@ -11,10 +11,10 @@ import (
 // InsecureUserInputRule проверяет код на небезопасную обработку пользовательского ввода
 type InsecureUserInputRule struct {
 	BaseRule
-	// Паттерны для определения источников пользовательского ввода
-	userInputSources []string
 	// Опасные функции для пользовательского ввода
 	unsafeFunctions map[string]bool
+	// Функции работы с путями и индекс аргумента с путем (-1 для всех аргументов)
+	pathTraversalSinks map[string]int
 	// Регулярные выражения для определения потенциальных инъекций в команды системы
 	commandInjectionRegex *regexp.Regexp
 	// Регулярные выражения для определения потенциальных XSS уязвимостей
@@ -28,12 +28,10 @@ func NewInsecureUserInputRule() *InsecureUserInputRule {
 			id:          "SEC006",
 			description: "Небезопасная обработка пользовательского ввода",
 			severity:    report.SeverityHigh,
-		},
-		userInputSources: []string{
-			"r.URL", "r.Form", "r.PostForm", "r.MultipartForm", "r.FormValue",
-			"r.PostFormValue", "r.QueryParam", "r.Query", "r.Param", "r.Body",
-			"json.Unmarshal", "json.Decode", "xml.Unmarshal", "xml.Decode",
-			"ioutil.ReadAll", "bufio.Scanner", "bufio.Reader",
+			cwe:         "CWE-20",
+			addedIn:     "0.1.0",
+			remediation: "Проверяйте пользовательский ввод по списку разрешенных значений и экранируйте его для контекста использования",
+			references:  []string{"https://cheatsheetseries.owasp.org/cheatsheets/Input_Validation_Cheat_Sheet.html"},
 		},
 		unsafeFunctions: map[string]bool{
 			"exec.Command":       true,
@@ -47,9 +45,6 @@ func NewInsecureUserInputRule() *InsecureUserInputRule {
 			"os.Open":            true,
 			"ioutil.WriteFile":   true,
 			"os.Create":          true,
-			"http.Get":           true,
-			"http.Post":          true,
-			"http.Do":            true,
 			"filepath.Abs":       true,
 			"filepath.Join":      true,
 			"io.Copy":            true,
@@ -58,67 +53,43 @@ func NewInsecureUserInputRule() *InsecureUserInputRule {
 			"strconv.ParseFloat": true,
 			"strconv.ParseBool":  true,
 		},
+		pathTraversalSinks: map[string]int{
+			"filepath.Join":  -1,
+			"os.Open":        0,
+			"os.ReadFile":    0,
+			"http.ServeFile": 2,
+		},
 		commandInjectionRegex: regexp.MustCompile(`(?i)(sh|bash|cmd|powershell|exec|system|popen|run|spawn)`),
 		xssRegex:              regexp.MustCompile(`(?i)(innerHTML|outerHTML|document\.write|eval\(|setTimeout\(|setInterval\(|new\s+Function\()`),
 	}
 }
 
+// Applicable проверяет, применимо ли правило к файлу: без веб-фреймворка
+// меньше шансов на проблемы с пользовательским вводом, такие файлы пропускаются
+func (r *InsecureUserInputRule) Applicable(ctx *Context) bool {
+	return hasWebFramework(ctx)
+}
+
 // Check реализует интерфейс Rule
 func (r *InsecureUserInputRule) Check(ctx *Context) []report.Issue {
 	var issues []report.Issue
 
-	// Проверяем, есть ли импорты веб-фреймворков
-	hasWebFramework := r.hasWebFramework(ctx)
-	if !hasWebFramework {
-		// Если нет веб-фреймворка, то меньше шансов на проблемы с пользовательским вводом
-		return issues
-	}
-
-	// Карта для отслеживания переменных, которые содержат пользовательский ввод
-	userInputVars := make(map[string]bool)
-
 	// Первый проход: определяем переменные, содержащие пользовательский ввод
-	ast.Inspect(ctx.File, func(n ast.Node) bool {
-		switch node := n.(type) {
-		case *ast.AssignStmt:
-			// Проверяем присваивания, где справа находится источник пользовательского ввода
-			for i, rhs := range node.Rhs {
-				if i >= len(node.Lhs) {
-					continue
-				}
-
-				if r.isUserInputSource(rhs) {
-					if ident, ok := node.Lhs[i].(*ast.Ident); ok {
-						userInputVars[ident.Name] = true
-					}
-				}
-			}
-
-		case *ast.ValueSpec:
-			// Проверяем объявления переменных
-			for i, val := range node.Values {
-				if i >= len(node.Names) {
-					continue
-				}
-
-				if r.isUserInputSource(val) {
-					userInputVars[node.Names[i].Name] = true
-				}
-			}
-		}
-
-		return true
-	})
+	tracker := NewTaintTracker(ctx)
 
 	// Второй проход: ищем небезопасное использование пользовательского ввода
 	ast.Inspect(ctx.File, func(n ast.Node) bool {
 		if callExpr, ok := n.(*ast.CallExpr); ok {
 			// Проверяем вызовы функций, которые могут быть небезопасными с пользовательским вводом
 			if sel, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+				// Функции работы с путями проверяются отдельно на обход пути
+				if _, ok := r.pathTraversalSinks[astToString(sel)]; ok {
+					return true
+				}
 				if r.isUnsafeFunction(sel) {
 					// Проверяем, передается ли пользовательский ввод в небезопасную функцию
 					for _, arg := range callExpr.Args {
-						if r.containsUserInput(arg, userInputVars) {
+						if tracker.IsTainted(arg) {
 							// Определяем тип проблемы безопасности
 							var message string
 							switch {
@@ -142,11 +113,169 @@ func (r *InsecureUserInputRule) Check(ctx *Context) []report.Issue {
 		return true
 	})
 
+	// Третий проход: обход пути в функциях работы с файлами
+	for _, decl := range ctx.File.Decls {
+		if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+			issues = append(issues, r.checkPathTraversal(funcDecl.Body, tracker, ctx)...)
+		}
+	}
+
 	return issues
 }
 
+// checkPathTraversal ищет передачу пользовательского ввода в функции работы с путями
+// без проверки вида strings.HasPrefix(filepath.Clean(p), base)
+func (r *InsecureUserInputRule) checkPathTraversal(body *ast.BlockStmt, tracker *TaintTracker, ctx *Context) []report.Issue {
+	var issues []report.Issue
+
+	// Переменные, производные от пользовательского ввода: p := filepath.Join(base, name)
+	tainted := tracker.Propagate(body)
+
+	// Источники значений filepath.Clean: cleaned := filepath.Clean(p)
+	cleanSources := make(map[string][]string)
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			if i >= len(assign.Lhs) {
+				continue
+			}
+			if ident, ok := assign.Lhs[i].(*ast.Ident); ok {
+				if inner, ok := filepathCleanArg(rhs); ok {
+					cleanSources[ident.Name] = append(cleanSources[ident.Name], identNames(inner)...)
+				}
+			}
+		}
+		return true
+	})
+
+	// Переменные, прошедшие проверку вхождения в базовую директорию
+	validated := make(map[string]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || astToString(sel) != "strings.HasPrefix" {
+			return true
+		}
+
+		if inner, ok := filepathCleanArg(call.Args[0]); ok {
+			// strings.HasPrefix(filepath.Clean(p), base)
+			for _, name := range identNames(inner) {
+				validated[name] = true
+			}
+		} else if ident, ok := call.Args[0].(*ast.Ident); ok {
+			// cleaned := filepath.Clean(p); strings.HasPrefix(cleaned, base)
+			if sources, ok := cleanSources[ident.Name]; ok {
+				validated[ident.Name] = true
+				for _, name := range sources {
+					validated[name] = true
+				}
+			}
+		}
+		return true
+	})
+
+	// Результат filepath.Join, присвоенный проверенной переменной, считается безопасным
+	validatedJoins := make(map[*ast.CallExpr]bool)
+	ast.Inspect(body, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok {
+			return true
+		}
+		for i, rhs := range assign.Rhs {
+			if i >= len(assign.Lhs) {
+				continue
+			}
+			ident, ok := assign.Lhs[i].(*ast.Ident)
+			if !ok || !validated[ident.Name] {
+				continue
+			}
+			// Учитываем обертку filepath.Clean(filepath.Join(base, name))
+			for expr := rhs; expr != nil; {
+				call, ok := expr.(*ast.CallExpr)
+				if !ok {
+					break
+				}
+				validatedJoins[call] = true
+				expr, _ = filepathCleanArg(call)
+			}
+		}
+		return true
+	})
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || validatedJoins[call] {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		funcName := astToString(sel)
+		argIndex, ok := r.pathTraversalSinks[funcName]
+		if !ok {
+			return true
+		}
+
+		args := call.Args
+		if argIndex >= 0 {
+			if argIndex >= len(args) {
+				return true
+			}
+			args = args[argIndex : argIndex+1]
+		}
+
+		for _, arg := range args {
+			if tainted.Derives(arg) && !isValidatedPath(arg, validated) {
+				issues = append(issues, r.NewIssue(call.Pos(), ctx,
+					"Потенциальный обход пути (path traversal): пользовательский ввод передается в "+funcName+
+						" без проверки strings.HasPrefix(filepath.Clean(path), base)"))
+				break
+			}
+		}
+		return true
+	})
+
+	return issues
+}
+
+// filepathCleanArg возвращает аргумент вызова filepath.Clean
+func filepathCleanArg(expr ast.Expr) (ast.Expr, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, false
+	}
+	if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || astToString(sel) != "filepath.Clean" {
+		return nil, false
+	}
+	return call.Args[0], true
+}
+
+// identNames возвращает имена всех идентификаторов в выражении
+func identNames(expr ast.Expr) []string {
+	var names []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			names = append(names, ident.Name)
+		}
+		return true
+	})
+	return names
+}
+
+// isValidatedPath проверяет, что аргумент является переменной, прошедшей проверку пути
+func isValidatedPath(expr ast.Expr, validated map[string]bool) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && validated[ident.Name]
+}
+
 // hasWebFramework проверяет, используется ли веб-фреймворк в коде
-func (r *InsecureUserInputRule) hasWebFramework(ctx *Context) bool {
+func hasWebFramework(ctx *Context) bool {
 	// Если есть импорт веб-фреймворка, возвращаем true
 	for _, imp := range ctx.File.Imports {
 		if imp.Path != nil {
@@ -185,30 +314,6 @@ func (r *InsecureUserInputRule) hasWebFramework(ctx *Context) bool {
 	return hasHttpHandler
 }
 
-// isUserInputSource проверяет, является ли выражение источником пользовательского ввода
-func (r *InsecureUserInputRule) isUserInputSource(expr ast.Expr) bool {
-	switch node := expr.(type) {
-	case *ast.SelectorExpr:
-		exprStr := astToString(node)
-		for _, source := range r.userInputSources {
-			if strings.Contains(exprStr, source) {
-				return true
-			}
-		}
-	case *ast.CallExpr:
-		// Проверяем, является ли вызов функции источником пользовательского ввода
-		if sel, ok := node.Fun.(*ast.SelectorExpr); ok {
-			exprStr := astToString(sel)
-			for _, source := range r.userInputSources {
-				if strings.Contains(exprStr, source) {
-					return true
-				}
-			}
-		}
-	}
-	return false
-}
-
 // isUnsafeFunction проверяет, является ли селектор ссылкой на небезопасную функцию
 func (r *InsecureUserInputRule) isUnsafeFunction(sel *ast.SelectorExpr) bool {
 	if x, ok := sel.X.(*ast.Ident); ok {
@@ -227,34 +332,6 @@ func (r *InsecureUserInputRule) isUnsafeFunction(sel *ast.SelectorExpr) bool {
 		r.xssRegex.MatchString(exprStr)
 }
 
-// containsUserInput проверяет, содержит ли выражение пользовательский ввод
-func (r *InsecureUserInputRule) containsUserInput(expr ast.Expr, userInputVars map[string]bool) bool {
-	switch node := expr.(type) {
-	case *ast.Ident:
-		// Проверяем, является ли идентификатор пользовательским вводом
-		return userInputVars[node.Name]
-	case *ast.SelectorExpr:
-		// Проверяем, является ли селектор пользовательским вводом
-		exprStr := astToString(node)
-		for _, source := range r.userInputSources {
-			if strings.Contains(exprStr, source) {
-				return true
-			}
-		}
-	case *ast.BinaryExpr:
-		// Проверяем, содержат ли части бинарного выражения пользовательский ввод
-		return r.containsUserInput(node.X, userInputVars) || r.containsUserInput(node.Y, userInputVars)
-	case *ast.CallExpr:
-		// Проверяем аргументы вызова функции
-		for _, arg := range node.Args {
-			if r.containsUserInput(arg, userInputVars) {
-				return true
-			}
-		}
-	}
-	return false
-}
-
 // astToString преобразует AST-выражение в строку для примерного анализа
 func astToString(expr ast.Expr) string {
 	switch node := expr.(type) {
@@ -1,28 +1,63 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+
+	"go-audit/pkg/report"
+	"gopkg.in/yaml.v3"
 )
 
 // Config представляет конфигурацию линтера
 type Config struct {
 	// Список идентификаторов правил для включения (пустой означает, что все правила включены)
-	EnabledRules []string `json:"enabledRules,omitempty"`
+	EnabledRules []string `json:"enabledRules,omitempty" yaml:"enabledRules,omitempty"`
 
 	// Список идентификаторов правил для отключения (имеет приоритет над EnabledRules)
-	DisabledRules []string `json:"disabledRules,omitempty"`
+	DisabledRules []string `json:"disabledRules,omitempty" yaml:"disabledRules,omitempty"`
 
 	// Пользовательские переопределения серьезности для конкретных правил
-	SeverityOverrides map[string]string `json:"severityOverrides,omitempty"`
+	SeverityOverrides map[string]string `json:"severityOverrides,omitempty" yaml:"severityOverrides,omitempty"`
+
+	// Правила, проблемы которых понижаются до INFO без отключения (имеет приоритет над SeverityOverrides)
+	DemotedRules []string `json:"demotedRules,omitempty" yaml:"demotedRules,omitempty"`
 
 	// Список шаблонов файлов или директорий для исключения
-	Exclude []string `json:"exclude,omitempty"`
+	Exclude []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 
 	// Настройки конкретных правил
-	RuleSettings map[string]map[string]interface{} `json:"ruleSettings,omitempty"`
+	RuleSettings map[string]map[string]interface{} `json:"ruleSettings,omitempty" yaml:"ruleSettings,omitempty"`
+
+	// Список CWE, при наличии проблем с которыми проверка завершается с ошибкой
+	FailOnCWE []string `json:"failOnCwe,omitempty" yaml:"failOnCwe,omitempty"`
+
+	// Максимальное количество файлов или пакетов, анализируемых одновременно
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+
+	// Пропускать сгенерированные файлы с заголовком "// Code generated ... DO NOT EDIT."
+	SkipGenerated bool `json:"skipGenerated" yaml:"skipGenerated"`
+
+	// Правила, отключенные для файлов по шаблону пути
+	PathRuleOverrides []PathRuleOverride `json:"pathRuleOverrides,omitempty" yaml:"pathRuleOverrides,omitempty"`
+
+	// Веса уровней серьезности для оценки риска в отчете; для не указанных уровней используются веса по умолчанию
+	ScoreWeights map[string]int `json:"scoreWeights,omitempty" yaml:"scoreWeights,omitempty"`
+}
+
+// PathRuleOverride отключает правила для файлов, путь которых соответствует шаблону
+type PathRuleOverride struct {
+	// Шаблон пути в формате exclude, например cmd/** или internal/*/testutil
+	PathGlob string `json:"pathGlob" yaml:"pathGlob"`
+	// Правила, проблемы которых в подходящих файлах подавляются
+	DisableRules []string `json:"disableRules" yaml:"disableRules"`
 }
 
 // DefaultConfig возвращает конфигурацию по умолчанию
@@ -37,20 +72,68 @@ func DefaultConfig() *Config {
 			"testdata/",
 			"*_test.go",
 		},
-		RuleSettings: map[string]map[string]interface{}{},
+		RuleSettings:  map[string]map[string]interface{}{},
+		Concurrency:   runtime.NumCPU(),
+		SkipGenerated: true,
 	}
 }
 
-// Load загружает конфигурацию из JSON-файла
+// ConfigFileName имя файла конфигурации, который ищется при запуске без -config
+const ConfigFileName = ".gosecheck.json"
+
+// configFileNames имена файлов конфигурации в порядке приоритета при поиске
+var configFileNames = []string{ConfigFileName, ".gosecheck.yaml", ".gosecheck.yml"}
+
+// FindConfig ищет файл конфигурации в директории dir и ее родителях, как git ищет .gitignore.
+// В каждой директории проверяются .gosecheck.json, .gosecheck.yaml и .gosecheck.yml.
+// Поиск прекращается в корне файловой системы или в директории с go.mod, если в ней нет конфигурации.
+// Возвращает путь к найденному файлу или пустую строку.
+func FindConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		for _, name := range configFileNames {
+			candidate := filepath.Join(dir, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+		// Корень модуля является границей проекта
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// isYAML проверяет по расширению, что файл конфигурации записан в формате YAML
+func isYAML(configPath string) bool {
+	ext := strings.ToLower(filepath.Ext(configPath))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Load загружает конфигурацию из файла: YAML для расширений .yaml и .yml, JSON для остальных.
+// При пустом пути используется файл, найденный FindConfig от текущей директории,
+// а если он не найден - конфигурация по умолчанию.
 func Load(configPath string) (*Config, error) {
 	config := DefaultConfig()
 
-	// Если файл конфигурации не указан, используем значения по умолчанию
+	// Если файл конфигурации не указан, ищем его от текущей директории вверх
 	if configPath == "" {
-		// Ищем .gosecheck.json в текущей директории
-		if _, err := os.Stat(".gosecheck.json"); err == nil {
-			configPath = ".gosecheck.json"
-		} else {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		configPath = FindConfig(wd)
+		if configPath == "" {
 			return config, nil
 		}
 	}
@@ -61,17 +144,168 @@ func Load(configPath string) (*Config, error) {
 		return nil, err
 	}
 
-	err = json.Unmarshal(data, config)
-	if err != nil {
-		return nil, err
+	if err := decode(configPath, data, config); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
 	}
 
 	return config, nil
 }
 
-// Save записывает конфигурацию в указанный файл
+// decode разбирает конфигурацию в формате JSON или YAML в зависимости от расширения файла.
+// Неизвестные параметры обычно означают опечатку, которая иначе молча игнорируется, поэтому они запрещены.
+func decode(configPath string, data []byte, config *Config) error {
+	if isYAML(configPath) {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		// Пустой файл YAML означает конфигурацию по умолчанию
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(config); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return fmt.Errorf("неизвестный параметр конфигурации %s", field)
+		}
+		return err
+	}
+	return nil
+}
+
+// Validate проверяет корректность значений конфигурации.
+// Ошибка указывает параметр с некорректным значением.
+func (c *Config) Validate() error {
+	for i, ruleID := range c.EnabledRules {
+		if strings.TrimSpace(ruleID) == "" {
+			return fmt.Errorf("enabledRules[%d]: пустой идентификатор правила", i)
+		}
+	}
+	for i, ruleID := range c.DisabledRules {
+		if strings.TrimSpace(ruleID) == "" {
+			return fmt.Errorf("disabledRules[%d]: пустой идентификатор правила", i)
+		}
+	}
+	for ruleID := range c.SeverityOverrides {
+		if strings.TrimSpace(ruleID) == "" {
+			return fmt.Errorf("severityOverrides: пустой идентификатор правила")
+		}
+	}
+	for i, ruleID := range c.DemotedRules {
+		if strings.TrimSpace(ruleID) == "" {
+			return fmt.Errorf("demotedRules[%d]: пустой идентификатор правила", i)
+		}
+	}
+	for ruleID := range c.RuleSettings {
+		if strings.TrimSpace(ruleID) == "" {
+			return fmt.Errorf("ruleSettings: пустой идентификатор правила")
+		}
+	}
+
+	ruleIDs := make([]string, 0, len(c.SeverityOverrides))
+	for ruleID := range c.SeverityOverrides {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+
+	for _, ruleID := range ruleIDs {
+		if _, err := report.ParseSeverity(c.SeverityOverrides[ruleID]); err != nil {
+			return fmt.Errorf("severityOverrides[%s]: %w", ruleID, err)
+		}
+	}
+
+	for i, override := range c.PathRuleOverrides {
+		if strings.TrimSpace(override.PathGlob) == "" {
+			return fmt.Errorf("pathRuleOverrides[%d]: пустой шаблон пути", i)
+		}
+		for j, ruleID := range override.DisableRules {
+			if strings.TrimSpace(ruleID) == "" {
+				return fmt.Errorf("pathRuleOverrides[%d].disableRules[%d]: пустой идентификатор правила", i, j)
+			}
+		}
+	}
+
+	severities := make([]string, 0, len(c.ScoreWeights))
+	for severity := range c.ScoreWeights {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+
+	for _, severity := range severities {
+		if _, err := report.ParseSeverity(severity); err != nil {
+			return fmt.Errorf("scoreWeights: %w", err)
+		}
+		if c.ScoreWeights[severity] < 0 {
+			return fmt.Errorf("scoreWeights[%s]: вес должен быть неотрицательным, получено %d", severity, c.ScoreWeights[severity])
+		}
+	}
+
+	return nil
+}
+
+// ValidateRuleIDs проверяет, что правила в enabledRules, disabledRules, severityOverrides,
+// demotedRules и pathRuleOverrides есть среди известных, и возвращает ошибку со списком неизвестных.
+// Набор правил определяется анализатором, поэтому проверка выполняется отдельно от Validate.
+func (c *Config) ValidateRuleIDs(known []string) error {
+	knownSet := make(map[string]bool, len(known))
+	for _, id := range known {
+		knownSet[id] = true
+	}
+
+	var unknown []string
+	check := func(field, ruleID string) {
+		if !knownSet[ruleID] {
+			unknown = append(unknown, field+": "+ruleID)
+		}
+	}
+
+	for _, ruleID := range c.EnabledRules {
+		check("enabledRules", ruleID)
+	}
+	for _, ruleID := range c.DisabledRules {
+		check("disabledRules", ruleID)
+	}
+
+	overrideIDs := make([]string, 0, len(c.SeverityOverrides))
+	for ruleID := range c.SeverityOverrides {
+		overrideIDs = append(overrideIDs, ruleID)
+	}
+	sort.Strings(overrideIDs)
+	for _, ruleID := range overrideIDs {
+		check("severityOverrides", ruleID)
+	}
+	for _, ruleID := range c.DemotedRules {
+		check("demotedRules", ruleID)
+	}
+
+	for i, override := range c.PathRuleOverrides {
+		for _, ruleID := range override.DisableRules {
+			check(fmt.Sprintf("pathRuleOverrides[%d]", i), ruleID)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("неизвестные правила: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Save записывает конфигурацию в указанный файл в формате YAML для расширений .yaml и .yml,
+// иначе в формате JSON
 func (c *Config) Save(configPath string) error {
-	data, err := json.MarshalIndent(c, "", "  ")
+	var data []byte
+	var err error
+	if isYAML(configPath) {
+		data, err = yaml.Marshal(c)
+	} else {
+		data, err = json.MarshalIndent(c, "", "  ")
+	}
 	if err != nil {
 		return err
 	}
@@ -79,11 +313,24 @@ func (c *Config) Save(configPath string) error {
 	return os.WriteFile(configPath, data, 0644)
 }
 
-// ShouldExclude проверяет, должен ли файл быть исключен на основе конфигурации
+// ShouldExclude проверяет, должен ли файл быть исключен на основе конфигурации.
+//
+// Относительные пути и шаблоны сопоставляются с путем относительно текущей директории
+// (корня сканирования) с разделителем "/":
+//   - шаблон из одного сегмента ("*_test.go", "vendor") соответствует имени файла
+//     или директории на любой глубине;
+//   - шаблон с завершающим "/" ("vendor/") соответствует только директориям;
+//   - шаблон из нескольких сегментов ("cmd/*/main.go", "internal/generated") сопоставляется
+//     по сегментам с началом пути: "*" не выходит за пределы сегмента, а совпадение
+//     с начальными сегментами исключает все файлы директории;
+//   - "**" соответствует любому числу сегментов, включая ноль: "**/mocks/**",
+//     "internal/**/*.pb.go";
+//   - абсолютный путь к директории исключает все файлы внутри нее.
 func (c *Config) ShouldExclude(path string) bool {
+	segments := relativeSegments(path)
+
 	for _, pattern := range c.Exclude {
-		matched, err := filepath.Match(pattern, filepath.Base(path))
-		if err == nil && matched {
+		if !filepath.IsAbs(pattern) && matchExcludePattern(pattern, segments) {
 			return true
 		}
 
@@ -99,6 +346,21 @@ func (c *Config) ShouldExclude(path string) bool {
 	return false
 }
 
+// IsRuleDisabledForPath проверяет, отключено ли правило для файла переопределениями pathRuleOverrides
+func (c *Config) IsRuleDisabledForPath(ruleID, path string) bool {
+	if len(c.PathRuleOverrides) == 0 {
+		return false
+	}
+
+	segments := relativeSegments(path)
+	for _, override := range c.PathRuleOverrides {
+		if containsString(override.DisableRules, ruleID) && matchExcludePattern(override.PathGlob, segments) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsRuleEnabled проверяет, включено ли правило
 func (c *Config) IsRuleEnabled(ruleID string) bool {
 	// Сначала проверяем, явно ли отключено правило
@@ -123,6 +385,69 @@ func (c *Config) IsRuleEnabled(ruleID string) bool {
 	return false
 }
 
+// OverrideRules применяет списки правил из командной строки поверх конфигурации.
+// Непустой список enabled заменяет EnabledRules, а перечисленные в нем правила убираются
+// из DisabledRules; правила из disabled добавляются к DisabledRules.
+func (c *Config) OverrideRules(enabled, disabled []string) {
+	if len(enabled) > 0 {
+		c.EnabledRules = append([]string(nil), enabled...)
+
+		var kept []string
+		for _, id := range c.DisabledRules {
+			if !containsString(enabled, id) {
+				kept = append(kept, id)
+			}
+		}
+		c.DisabledRules = kept
+	}
+
+	for _, id := range disabled {
+		if !containsString(c.DisabledRules, id) {
+			c.DisabledRules = append(c.DisabledRules, id)
+		}
+	}
+}
+
+// containsString проверяет наличие строки в списке
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveSeverity возвращает уровень серьезности правила с учетом переопределений из конфигурации.
+// Пониженные правила всегда получают INFO и скрываются порогом -min-severity выше INFO.
+func (c *Config) ResolveSeverity(ruleID string, base report.Severity) report.Severity {
+	if containsString(c.DemotedRules, ruleID) {
+		return report.SeverityInfo
+	}
+
+	override, ok := c.SeverityOverrides[ruleID]
+	if !ok {
+		return base
+	}
+
+	severity, err := report.ParseSeverity(override)
+	if err != nil {
+		return base
+	}
+	return severity
+}
+
+// ResolveScoreWeights возвращает веса серьезности для оценки риска: веса по умолчанию с переопределениями из конфигурации
+func (c *Config) ResolveScoreWeights() map[report.Severity]int {
+	weights := report.DefaultScoreWeights()
+	for value, weight := range c.ScoreWeights {
+		if severity, err := report.ParseSeverity(value); err == nil {
+			weights[severity] = weight
+		}
+	}
+	return weights
+}
+
 // GetRuleSettings получает пользовательские настройки для конкретного правила
 func (c *Config) GetRuleSettings(ruleID string) map[string]interface{} {
 	if settings, ok := c.RuleSettings[ruleID]; ok {
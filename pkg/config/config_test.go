@@ -2,11 +2,15 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	"go-audit/pkg/report"
 )
 
 // TestDefaultConfig проверяет создание конфигурации по умолчанию
@@ -33,6 +37,10 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("Exclude должен быть %v, получено: %v", expectedExcludes, cfg.Exclude)
 	}
 
+	if !cfg.SkipGenerated {
+		t.Error("SkipGenerated должен быть включен по умолчанию")
+	}
+
 	// Проверяем, что карта переопределений серьезности пуста
 	if len(cfg.SeverityOverrides) != 0 {
 		t.Errorf("SeverityOverrides должен быть пустой картой, получено: %v", cfg.SeverityOverrides)
@@ -132,6 +140,84 @@ func TestLoadAndSave(t *testing.T) {
 	}
 }
 
+// TestLoadAndSaveYAML проверяет загрузку и сохранение конфигурации в формате YAML
+func TestLoadAndSaveYAML(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	content := `enabledRules: [SEC001, SEC003]
+disabledRules:
+  - SEC002
+severityOverrides:
+  SEC001: HIGH
+  SEC003: MEDIUM
+exclude:
+  - vendor/
+  - testdata/
+  - generated/
+ruleSettings:
+  SEC001:
+    customParam: value
+    threshold: 10
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+
+	loadedConfig, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Ошибка загрузки конфигурации: %v", err)
+	}
+
+	expected := &Config{
+		EnabledRules:  []string{"SEC001", "SEC003"},
+		DisabledRules: []string{"SEC002"},
+		SeverityOverrides: map[string]string{
+			"SEC001": "HIGH",
+			"SEC003": "MEDIUM",
+		},
+		Exclude: []string{"vendor/", "testdata/", "generated/"},
+	}
+	if !reflect.DeepEqual(loadedConfig.EnabledRules, expected.EnabledRules) ||
+		!reflect.DeepEqual(loadedConfig.DisabledRules, expected.DisabledRules) ||
+		!reflect.DeepEqual(loadedConfig.SeverityOverrides, expected.SeverityOverrides) ||
+		!reflect.DeepEqual(loadedConfig.Exclude, expected.Exclude) {
+		t.Errorf("Загруженная конфигурация не соответствует ожидаемой: %+v", loadedConfig)
+	}
+	if got := loadedConfig.GetRuleSettings("SEC001")["customParam"]; got != "value" {
+		t.Errorf("ruleSettings.SEC001.customParam = %v, ожидалось value", got)
+	}
+
+	// Сохраняем в YAML и загружаем повторно
+	savePath := filepath.Join(dir, "saved.yml")
+	if err := loadedConfig.Save(savePath); err != nil {
+		t.Fatalf("Ошибка сохранения конфигурации: %v", err)
+	}
+	data, err := os.ReadFile(savePath)
+	if err != nil {
+		t.Fatalf("Ошибка чтения сохраненной конфигурации: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "enabledRules:") {
+		t.Errorf("Конфигурация должна быть сохранена в формате YAML:\n%s", data)
+	}
+
+	savedConfig, err := Load(savePath)
+	if err != nil {
+		t.Fatalf("Ошибка загрузки сохраненной конфигурации: %v", err)
+	}
+	if !reflect.DeepEqual(savedConfig, loadedConfig) {
+		t.Error("Сохраненная конфигурация не соответствует исходной")
+	}
+
+	// Неизвестный параметр в YAML также приводит к ошибке
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidPath, []byte("enabledRule: [SEC001]\n"), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+	if _, err := Load(invalidPath); err == nil || !strings.Contains(err.Error(), "enabledRule") {
+		t.Errorf("Ожидалась ошибка с указанием неизвестного параметра, получено: %v", err)
+	}
+}
+
 // TestLoadDefault проверяет загрузку конфигурации по умолчанию
 func TestLoadDefault(t *testing.T) {
 	// Загружаем конфигурацию без указания файла
@@ -147,6 +233,71 @@ func TestLoadDefault(t *testing.T) {
 	}
 }
 
+// TestFindConfig проверяет поиск файла конфигурации в родительских директориях
+func TestFindConfig(t *testing.T) {
+	root := t.TempDir()
+	configPath := filepath.Join(root, ConfigFileName)
+	if err := os.WriteFile(configPath, []byte(`{"disabledRules": ["SEC002"]}`), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+
+	nested := filepath.Join(root, "internal", "service")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Ошибка создания директории: %v", err)
+	}
+
+	if found := FindConfig(nested); found != configPath {
+		t.Errorf("FindConfig(%s) = %q, ожидалось %q", nested, found, configPath)
+	}
+	if found := FindConfig(root); found != configPath {
+		t.Errorf("FindConfig(%s) = %q, ожидалось %q", root, found, configPath)
+	}
+
+	// Load без пути использует конфигурацию, найденную от текущей директории
+	t.Chdir(nested)
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Ошибка загрузки конфигурации: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.DisabledRules, []string{"SEC002"}) {
+		t.Errorf("DisabledRules = %v, ожидалось [SEC002]", cfg.DisabledRules)
+	}
+
+	// go.mod без конфигурации останавливает поиск
+	module := filepath.Join(root, "internal")
+	if err := os.WriteFile(filepath.Join(module, "go.mod"), []byte("module example.com/service\n"), 0644); err != nil {
+		t.Fatalf("Ошибка записи go.mod: %v", err)
+	}
+	if found := FindConfig(nested); found != "" {
+		t.Errorf("FindConfig(%s) = %q, ожидался пустой путь из-за go.mod", nested, found)
+	}
+
+	// Конфигурация рядом с go.mod находится
+	moduleConfig := filepath.Join(module, ConfigFileName)
+	if err := os.WriteFile(moduleConfig, []byte(`{}`), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+	if found := FindConfig(nested); found != moduleConfig {
+		t.Errorf("FindConfig(%s) = %q, ожидалось %q", nested, found, moduleConfig)
+	}
+
+	// Конфигурация в формате YAML находится так же, как JSON
+	yamlConfig := filepath.Join(nested, ".gosecheck.yaml")
+	if err := os.WriteFile(yamlConfig, []byte("disabledRules: [SEC003]\n"), 0644); err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+	if found := FindConfig(nested); found != yamlConfig {
+		t.Errorf("FindConfig(%s) = %q, ожидалось %q", nested, found, yamlConfig)
+	}
+	cfg, err = Load("")
+	if err != nil {
+		t.Fatalf("Ошибка загрузки конфигурации: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.DisabledRules, []string{"SEC003"}) {
+		t.Errorf("DisabledRules = %v, ожидалось [SEC003]", cfg.DisabledRules)
+	}
+}
+
 // TestLoadNonExistent проверяет загрузку несуществующего файла конфигурации
 func TestLoadNonExistent(t *testing.T) {
 	// Пытаемся загрузить несуществующий файл
@@ -179,6 +330,101 @@ func TestLoadInvalid(t *testing.T) {
 	}
 }
 
+// TestLoadInvalidSeverityOverride проверяет ошибку загрузки при неизвестном уровне серьезности
+func TestLoadInvalidSeverityOverride(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	err := os.WriteFile(configPath, []byte(`{"severityOverrides": {"SEC001": "SEVERE"}}`), 0644)
+	if err != nil {
+		t.Fatalf("Ошибка записи конфигурации: %v", err)
+	}
+
+	_, err = Load(configPath)
+	if err == nil {
+		t.Fatal("Ожидалась ошибка при загрузке недопустимого уровня серьезности")
+	}
+
+	if !strings.Contains(err.Error(), "SEC001") || !strings.Contains(err.Error(), "SEVERE") {
+		t.Errorf("Ошибка должна указывать правило и значение: %v", err)
+	}
+}
+
+// TestLoadValidation проверяет, что ошибки загрузки конфигурации указывают некорректный параметр
+func TestLoadValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		field   string
+	}{
+		{
+			name:    "unknown top-level key",
+			content: `{"enabledRule": ["SEC001"]}`,
+			field:   `"enabledRule"`,
+		},
+		{
+			name:    "bad severity value",
+			content: `{"severityOverrides": {"SEC003": "URGENT"}}`,
+			field:   "severityOverrides[SEC003]",
+		},
+		{
+			name:    "empty rule id",
+			content: `{"disabledRules": ["SEC001", ""]}`,
+			field:   "disabledRules[1]",
+		},
+		{
+			name:    "empty demoted rule id",
+			content: `{"demotedRules": [""]}`,
+			field:   "demotedRules[0]",
+		},
+		{
+			name:    "empty rule id in settings",
+			content: `{"ruleSettings": {"": {"minLength": 12}}}`,
+			field:   "ruleSettings",
+		},
+		{
+			name:    "wrong value type",
+			content: `{"concurrency": "four"}`,
+			field:   "concurrency",
+		},
+		{
+			name:    "unknown severity in score weights",
+			content: `{"scoreWeights": {"URGENT": 3}}`,
+			field:   "scoreWeights",
+		},
+		{
+			name:    "negative score weight",
+			content: `{"scoreWeights": {"HIGH": -1}}`,
+			field:   "scoreWeights[HIGH]",
+		},
+		{
+			name:    "empty path glob in override",
+			content: `{"pathRuleOverrides": [{"pathGlob": "", "disableRules": ["SEC006"]}]}`,
+			field:   "pathRuleOverrides[0]",
+		},
+		{
+			name:    "empty rule id in override",
+			content: `{"pathRuleOverrides": [{"pathGlob": "cmd/**", "disableRules": ["SEC006", ""]}]}`,
+			field:   "pathRuleOverrides[0].disableRules[1]",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(configPath, []byte(tc.content), 0644); err != nil {
+				t.Fatalf("Ошибка записи конфигурации: %v", err)
+			}
+
+			_, err := Load(configPath)
+			if err == nil {
+				t.Fatal("Ожидалась ошибка загрузки конфигурации")
+			}
+			if !strings.Contains(err.Error(), tc.field) || !strings.Contains(err.Error(), configPath) {
+				t.Errorf("Ошибка должна указывать файл и параметр %s: %v", tc.field, err)
+			}
+		})
+	}
+}
+
 // TestShouldExclude проверяет метод ShouldExclude
 func TestShouldExclude(t *testing.T) {
 	cfg := &Config{
@@ -229,6 +475,156 @@ func TestShouldExclude(t *testing.T) {
 	}
 }
 
+// TestShouldExcludeRelativePaths проверяет сопоставление шаблонов с путями относительно корня сканирования
+func TestShouldExcludeRelativePaths(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"cmd/*/main.go", "cmd/goaudit/main.go", true},
+		{"cmd/*/main.go", "./cmd/goaudit/main.go", true},
+		{"cmd/*/main.go", "cmd/goaudit/run.go", false},
+		{"cmd/*/main.go", "cmd/a/b/main.go", false},
+		{"cmd/*/main.go", "tools/cmd/goaudit/main.go", false},
+		{"internal/**/*.pb.go", "internal/api/service.pb.go", true},
+		{"internal/**/*.pb.go", "internal/api/service.go", false},
+		{"internal/generated/*.go", "internal/generated/models.go", true},
+		{"internal/generated/*.go", "internal/models.go", false},
+		{"internal/generated", "internal/generated/sub/models.go", true},
+		{"internal/generated/", "internal/generated", false},
+		{"vendor", "dir/vendor/pkg/file.go", true},
+		{"vendor/", "vendor.go", false},
+		{"*.pb.go", "api/v1/service.pb.go", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			cfg := &Config{Exclude: []string{tc.pattern}}
+			if got := cfg.ShouldExclude(tc.path); got != tc.expected {
+				t.Errorf("ShouldExclude(%q) с шаблоном %q = %v, ожидалось %v", tc.path, tc.pattern, got, tc.expected)
+			}
+		})
+	}
+
+	// Абсолютный путь внутри текущей директории сопоставляется как относительный
+	absPath, err := filepath.Abs(filepath.Join("internal", "generated", "models.go"))
+	if err != nil {
+		t.Fatalf("Ошибка получения абсолютного пути: %v", err)
+	}
+	cfg := &Config{Exclude: []string{"internal/generated/*.go"}}
+	if !cfg.ShouldExclude(absPath) {
+		t.Errorf("ShouldExclude(%q) = false, ожидалось true", absPath)
+	}
+}
+
+// TestShouldExcludeDoubleStar проверяет шаблоны с "**", пересекающим границы директорий
+func TestShouldExcludeDoubleStar(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"**/*_gen.go", "models_gen.go", true},
+		{"**/*_gen.go", "internal/api/v1/models_gen.go", true},
+		{"**/*_gen.go", "internal/api/models.go", false},
+		{"**/*_gen.go", "internal/gen/models.go", false},
+		{"a/**/b", "a/b/file.go", true},
+		{"a/**/b", "a/x/y/b/file.go", true},
+		{"a/**/b", "a/x/y/b", true},
+		{"a/**/b", "a/x/c/file.go", false},
+		{"a/**/b", "x/a/b/file.go", false},
+		{"**/mocks/**", "mocks/client.go", true},
+		{"**/mocks/**", "internal/service/mocks/client.go", true},
+		{"**/mocks/**", "internal/mocks.go", false},
+		{"internal/**/*.pb.go", "internal/service.pb.go", true},
+		{"internal/**/*.pb.go", "internal/api/v1/service.pb.go", true},
+		{"internal/**/*.pb.go", "pkg/api/service.pb.go", false},
+		{"internal/*/*.pb.go", "internal/api/v1/service.pb.go", false},
+		{"cmd/*.go", "cmd/goaudit/main.go", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pattern+" "+tc.path, func(t *testing.T) {
+			cfg := &Config{Exclude: []string{tc.pattern}}
+			if got := cfg.ShouldExclude(tc.path); got != tc.expected {
+				t.Errorf("ShouldExclude(%q) с шаблоном %q = %v, ожидалось %v", tc.path, tc.pattern, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestValidateRuleIDs проверяет обнаружение неизвестных идентификаторов правил
+func TestValidateRuleIDs(t *testing.T) {
+	known := []string{"SEC001", "SEC002", "SEC006"}
+
+	valid := &Config{
+		EnabledRules:      []string{"SEC001", "SEC002"},
+		DisabledRules:     []string{"SEC006"},
+		SeverityOverrides: map[string]string{"SEC001": "LOW"},
+		PathRuleOverrides: []PathRuleOverride{{PathGlob: "cmd/**", DisableRules: []string{"SEC006"}}},
+	}
+	if err := valid.ValidateRuleIDs(known); err != nil {
+		t.Errorf("ValidateRuleIDs() для известных правил вернул ошибку: %v", err)
+	}
+	if err := DefaultConfig().ValidateRuleIDs(known); err != nil {
+		t.Errorf("ValidateRuleIDs() для конфигурации по умолчанию вернул ошибку: %v", err)
+	}
+
+	invalid := &Config{
+		EnabledRules:      []string{"SEC001", "SEC010"},
+		DisabledRules:     []string{"SEC1O2"},
+		SeverityOverrides: map[string]string{"SEC099": "LOW", "SEC002": "HIGH"},
+		DemotedRules:      []string{"SEC077"},
+		PathRuleOverrides: []PathRuleOverride{{PathGlob: "cmd/**", DisableRules: []string{"SEC066"}}},
+	}
+	err := invalid.ValidateRuleIDs(known)
+	if err == nil {
+		t.Fatal("Ожидалась ошибка для неизвестных правил")
+	}
+	for _, expected := range []string{"enabledRules: SEC010", "disabledRules: SEC1O2", "severityOverrides: SEC099", "demotedRules: SEC077", "pathRuleOverrides[0]: SEC066"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Ошибка должна указывать %q: %v", expected, err)
+		}
+	}
+	if strings.Contains(err.Error(), "SEC001") || strings.Contains(err.Error(), "SEC002") {
+		t.Errorf("Ошибка не должна указывать известные правила: %v", err)
+	}
+}
+
+// TestIsRuleDisabledForPath проверяет отключение правил по шаблону пути
+func TestIsRuleDisabledForPath(t *testing.T) {
+	cfg := &Config{PathRuleOverrides: []PathRuleOverride{
+		{PathGlob: "cmd/**", DisableRules: []string{"SEC006"}},
+		{PathGlob: "*_mock.go", DisableRules: []string{"SEC002", "SEC004"}},
+	}}
+
+	testCases := []struct {
+		ruleID   string
+		path     string
+		expected bool
+	}{
+		{"SEC006", "cmd/goaudit/main.go", true},
+		{"SEC006", "cmd/main.go", true},
+		{"SEC006", "internal/api/handler.go", false},
+		{"SEC006", "internal/cmd.go", false},
+		{"SEC001", "cmd/goaudit/main.go", false},
+		{"SEC002", "internal/store/db_mock.go", true},
+		{"SEC004", "db_mock.go", true},
+		{"SEC006", "internal/store/db_mock.go", false},
+	}
+
+	for _, tc := range testCases {
+		if got := cfg.IsRuleDisabledForPath(tc.ruleID, tc.path); got != tc.expected {
+			t.Errorf("IsRuleDisabledForPath(%q, %q) = %v, ожидалось %v", tc.ruleID, tc.path, got, tc.expected)
+		}
+	}
+
+	if DefaultConfig().IsRuleDisabledForPath("SEC006", "cmd/main.go") {
+		t.Error("Конфигурация по умолчанию не должна отключать правила по пути")
+	}
+}
+
 // TestIsRuleEnabled проверяет метод IsRuleEnabled
 func TestIsRuleEnabled(t *testing.T) {
 	testCases := []struct {
@@ -303,6 +699,69 @@ func TestIsRuleEnabled(t *testing.T) {
 	}
 }
 
+// TestOverrideRules проверяет приоритет списков правил из командной строки над конфигурацией
+func TestOverrideRules(t *testing.T) {
+	testCases := []struct {
+		name         string
+		enabled      []string
+		disabled     []string
+		flagEnabled  []string
+		flagDisabled []string
+		wantEnabled  []string
+		wantDisabled []string
+	}{
+		{
+			name:         "Без флагов",
+			enabled:      []string{"SEC001"},
+			disabled:     []string{"SEC002"},
+			wantEnabled:  []string{"SEC001"},
+			wantDisabled: []string{"SEC002"},
+		},
+		{
+			name:         "Флаг rules заменяет enabledRules",
+			enabled:      []string{"SEC001", "SEC002"},
+			flagEnabled:  []string{"SEC003"},
+			wantEnabled:  []string{"SEC003"},
+			wantDisabled: nil,
+		},
+		{
+			name:         "Флаг rules снимает отключение",
+			disabled:     []string{"SEC001", "SEC006"},
+			flagEnabled:  []string{"SEC001", "SEC003"},
+			wantEnabled:  []string{"SEC001", "SEC003"},
+			wantDisabled: []string{"SEC006"},
+		},
+		{
+			name:         "Флаг skip-rules дополняет disabledRules",
+			disabled:     []string{"SEC002"},
+			flagDisabled: []string{"SEC006", "SEC002"},
+			wantEnabled:  nil,
+			wantDisabled: []string{"SEC002", "SEC006"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{EnabledRules: tc.enabled, DisabledRules: tc.disabled}
+			cfg.OverrideRules(tc.flagEnabled, tc.flagDisabled)
+
+			if fmt.Sprint(cfg.EnabledRules) != fmt.Sprint(tc.wantEnabled) {
+				t.Errorf("EnabledRules = %v, ожидалось %v", cfg.EnabledRules, tc.wantEnabled)
+			}
+			if fmt.Sprint(cfg.DisabledRules) != fmt.Sprint(tc.wantDisabled) {
+				t.Errorf("DisabledRules = %v, ожидалось %v", cfg.DisabledRules, tc.wantDisabled)
+			}
+		})
+	}
+
+	// Правило из обоих флагов отключено: DisabledRules имеет приоритет
+	cfg := &Config{}
+	cfg.OverrideRules([]string{"SEC001"}, []string{"SEC001"})
+	if cfg.IsRuleEnabled("SEC001") {
+		t.Error("Правило из -rules и -skip-rules должно быть отключено")
+	}
+}
+
 // TestGetRuleSettings проверяет метод GetRuleSettings
 func TestGetRuleSettings(t *testing.T) {
 	cfg := &Config{
@@ -347,3 +806,53 @@ func TestGetRuleSettings(t *testing.T) {
 		t.Errorf("GetRuleSettings(\"SEC003\") = %v, ожидалось nil", sec003Settings)
 	}
 }
+
+// TestResolveSeverity проверяет метод ResolveSeverity
+func TestResolveSeverity(t *testing.T) {
+	cfg := &Config{
+		SeverityOverrides: map[string]string{
+			"SEC001": "MEDIUM",
+			"SEC002": "low",
+			"SEC004": "HIGH",
+		},
+		DemotedRules: []string{"SEC004", "SEC006"},
+	}
+
+	testCases := []struct {
+		ruleID   string
+		base     report.Severity
+		expected report.Severity
+	}{
+		{ruleID: "SEC001", base: report.SeverityCritical, expected: report.SeverityMedium},
+		{ruleID: "SEC002", base: report.SeverityHigh, expected: report.SeverityLow},
+		{ruleID: "SEC003", base: report.SeverityHigh, expected: report.SeverityHigh},
+		{ruleID: "SEC004", base: report.SeverityMedium, expected: report.SeverityInfo},
+		{ruleID: "SEC006", base: report.SeverityLow, expected: report.SeverityInfo},
+	}
+
+	for _, tc := range testCases {
+		if severity := cfg.ResolveSeverity(tc.ruleID, tc.base); severity != tc.expected {
+			t.Errorf("ResolveSeverity(%q, %s) = %s, ожидалось %s", tc.ruleID, tc.base, severity, tc.expected)
+		}
+	}
+}
+
+// TestResolveScoreWeights проверяет объединение весов оценки риска по умолчанию с настроенными
+func TestResolveScoreWeights(t *testing.T) {
+	defaults := DefaultConfig().ResolveScoreWeights()
+	if !reflect.DeepEqual(defaults, report.DefaultScoreWeights()) {
+		t.Errorf("Веса по умолчанию = %v, ожидалось %v", defaults, report.DefaultScoreWeights())
+	}
+
+	cfg := &Config{ScoreWeights: map[string]int{"critical": 50, "INFO": 1}}
+	expected := map[report.Severity]int{
+		report.SeverityCritical: 50,
+		report.SeverityHigh:     5,
+		report.SeverityMedium:   2,
+		report.SeverityLow:      1,
+		report.SeverityInfo:     1,
+	}
+	if weights := cfg.ResolveScoreWeights(); !reflect.DeepEqual(weights, expected) {
+		t.Errorf("ResolveScoreWeights() = %v, ожидалось %v", weights, expected)
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// matchExcludePattern проверяет, соответствует ли путь, разбитый на сегменты, шаблону исключения
+func matchExcludePattern(pattern string, segments []string) bool {
+	pattern = filepath.ToSlash(pattern)
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" || len(segments) == 0 {
+		return false
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+
+	// Шаблон из одного сегмента соответствует имени файла или директории на любой глубине
+	if len(patternSegments) == 1 {
+		patternSegments = []string{"**", patternSegments[0]}
+	}
+
+	return matchSegments(patternSegments, segments, dirOnly)
+}
+
+// matchSegments сопоставляет сегменты шаблона с началом пути. "*" и другие метасимволы
+// path.Match действуют внутри одного сегмента, "**" соответствует любому числу сегментов.
+// Совпадение со всем путем означает файл, с началом пути - директорию, содержащую файл.
+func matchSegments(pattern, segments []string, dirOnly bool) bool {
+	if len(pattern) == 0 {
+		return len(segments) > 0 || !dirOnly
+	}
+
+	if pattern[0] == "**" {
+		// Завершающий "**" соответствует содержимому директории, но не ей самой
+		if len(pattern) == 1 {
+			return len(segments) > 0
+		}
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:], dirOnly) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], segments[1:], dirOnly)
+}
+
+// relativeSegments возвращает сегменты пути относительно текущей директории.
+// Абсолютные пути вне текущей директории остаются абсолютными.
+func relativeSegments(filePath string) []string {
+	if filepath.IsAbs(filePath) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, filePath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				filePath = rel
+			}
+		}
+	}
+
+	cleaned := filepath.ToSlash(filepath.Clean(filePath))
+	return strings.Split(strings.TrimPrefix(cleaned, "/"), "/")
+}
@@ -0,0 +1,128 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// baselineVersion версия формата файла базовой линии
+const baselineVersion = 1
+
+// BaselineFile представляет содержимое файла базовой линии
+type BaselineFile struct {
+	Version int             `json:"version"`
+	Issues  []BaselineEntry `json:"issues"`
+}
+
+// BaselineEntry запись о проблеме в базовой линии
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	RuleID      string `json:"ruleId"`
+	FilePath    string `json:"filePath"`
+	Message     string `json:"message"`
+}
+
+// Baseline набор известных проблем, которые не должны попадать в отчет
+type Baseline struct {
+	// Количество проблем с каждым отпечатком
+	counts map[string]int
+}
+
+// Fingerprint вычисляет стабильный отпечаток проблемы по правилу, файлу, сообщению
+// и нормализованному тексту строки. Номер строки не учитывается, поэтому перемещение
+// кода вверх или вниз не меняет отпечаток.
+func Fingerprint(issue Issue, lineContext string) string {
+	normalized := strings.Join(strings.Fields(lineContext), " ")
+
+	hash := sha256.New()
+	for _, part := range []string{issue.RuleID, filepath.ToSlash(issue.FilePath), issue.Message, normalized} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// WriteBaseline записывает проблемы в файл базовой линии.
+// Проблемы без отпечатка пропускаются.
+func WriteBaseline(path string, issues []Issue) error {
+	baseline := BaselineFile{Version: baselineVersion, Issues: []BaselineEntry{}}
+	for _, issue := range issues {
+		if issue.Fingerprint == "" {
+			continue
+		}
+		baseline.Issues = append(baseline.Issues, BaselineEntry{
+			Fingerprint: issue.Fingerprint,
+			RuleID:      issue.RuleID,
+			FilePath:    filepath.ToSlash(issue.FilePath),
+			Message:     issue.Message,
+		})
+	}
+
+	// Стабильный порядок записей, чтобы файл не менялся без изменения проблем
+	sort.Slice(baseline.Issues, func(i, j int) bool {
+		a, b := baseline.Issues[i], baseline.Issues[j]
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ошибка сериализации базовой линии: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("ошибка записи базовой линии: %w", err)
+	}
+	return nil
+}
+
+// LoadBaseline загружает базовую линию из файла
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка чтения базовой линии: %w", err)
+	}
+
+	var file BaselineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("ошибка разбора базовой линии: %w", err)
+	}
+	if file.Version != baselineVersion {
+		return nil, fmt.Errorf("неподдерживаемая версия базовой линии %d", file.Version)
+	}
+
+	baseline := &Baseline{counts: make(map[string]int)}
+	for _, entry := range file.Issues {
+		baseline.counts[entry.Fingerprint]++
+	}
+	return baseline, nil
+}
+
+// Filter разделяет проблемы на новые и уже известные базовой линии.
+// Каждая запись базовой линии скрывает не более одной проблемы с тем же отпечатком.
+func (b *Baseline) Filter(issues []Issue) (kept, matched []Issue) {
+	remaining := make(map[string]int, len(b.counts))
+	for fingerprint, count := range b.counts {
+		remaining[fingerprint] = count
+	}
+
+	for _, issue := range issues {
+		if issue.Fingerprint != "" && remaining[issue.Fingerprint] > 0 {
+			remaining[issue.Fingerprint]--
+			matched = append(matched, issue)
+			continue
+		}
+		kept = append(kept, issue)
+	}
+	return kept, matched
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// csvHeader заголовок CSV-отчета
+var csvHeader = []string{"severity", "ruleId", "file", "line", "column", "message"}
+
+// CSVReporter генерирует отчеты в формате CSV
+type CSVReporter struct{}
+
+// NewCSVReporter создает новый CSV репортер
+func NewCSVReporter() *CSVReporter {
+	return &CSVReporter{}
+}
+
+// Generate реализует интерфейс Reporter
+func (r *CSVReporter) Generate(issues []Issue) string {
+	sortIssues(issues)
+
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Sprintf("Ошибка генерации отчета в формате CSV: %v", err)
+	}
+
+	for _, issue := range issues {
+		record := []string{
+			string(issue.Severity),
+			issue.RuleID,
+			issue.FilePath,
+			strconv.Itoa(issue.Line),
+			strconv.Itoa(issue.Column),
+			issue.Message,
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Sprintf("Ошибка генерации отчета в формате CSV: %v", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Sprintf("Ошибка генерации отчета в формате CSV: %v", err)
+	}
+
+	return builder.String()
+}
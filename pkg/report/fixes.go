@@ -0,0 +1,64 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// FixesReporter генерирует JSON со списком предлагаемых исправлений для редакторов и инструментов автоисправления.
+// Проблемы без SuggestedFix пропускаются.
+type FixesReporter struct{}
+
+// NewFixesReporter создает новый репортер исправлений
+func NewFixesReporter() *FixesReporter {
+	return &FixesReporter{}
+}
+
+// FixesReport представляет структуру JSON-отчета исправлений
+type FixesReport struct {
+	Fixes []Fix `json:"fixes"`
+}
+
+// Fix предлагаемое исправление с проблемой, к которой оно относится
+type Fix struct {
+	RuleID   string `json:"ruleId"`
+	FilePath string `json:"filePath"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Message  string `json:"message"`
+	SuggestedFix
+}
+
+// Generate реализует интерфейс Reporter
+func (r *FixesReporter) Generate(issues []Issue) string {
+	fixes := []Fix{}
+	for _, issue := range issues {
+		if issue.SuggestedFix == nil {
+			continue
+		}
+		fixes = append(fixes, Fix{
+			RuleID:       issue.RuleID,
+			FilePath:     issue.FilePath,
+			Line:         issue.Line,
+			Column:       issue.Column,
+			Message:      issue.Message,
+			SuggestedFix: *issue.SuggestedFix,
+		})
+	}
+
+	// Исправления упорядочены по файлу и смещению, чтобы их было удобно применять последовательно
+	sort.SliceStable(fixes, func(i, j int) bool {
+		if fixes[i].FilePath != fixes[j].FilePath {
+			return fixes[i].FilePath < fixes[j].FilePath
+		}
+		return fixes[i].StartOffset < fixes[j].StartOffset
+	})
+
+	jsonData, err := json.MarshalIndent(FixesReport{Fixes: fixes}, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Ошибка генерации отчета исправлений: %v", err)
+	}
+
+	return string(jsonData)
+}
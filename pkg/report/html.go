@@ -0,0 +1,114 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// htmlTemplate шаблон автономного HTML-отчета
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>Go-audit - Отчет по анализу безопасности</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+.CRITICAL { background: #f8d7da; }
+.HIGH { background: #fde2c8; }
+.MEDIUM { background: #fff3cd; }
+.LOW { background: #d1ecf1; }
+.INFO { background: #e2e3e5; }
+</style>
+</head>
+<body>
+<h1>Go-audit - Отчет по анализу безопасности</h1>
+<p>Дата: {{.Timestamp}}</p>
+<p>Всего проблем: {{.Total}}</p>
+<h2>Сводка по серьезности проблем</h2>
+<table>
+<tr><th>Серьезность</th><th>Количество</th></tr>
+{{- range .Summary}}
+<tr class="{{.Severity}}"><td>{{.Severity}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+{{- range .Files}}
+<h2>Файл: {{.Path}}</h2>
+<table>
+<tr><th>Серьезность</th><th>Правило</th><th>Строка</th><th>Столбец</th><th>Сообщение</th><th>Описание</th><th>Рекомендация</th></tr>
+{{- range .Issues}}
+<tr class="{{.Severity}}"><td>{{.Severity}}</td><td>{{.RuleID}}</td><td>{{.Line}}</td><td>{{.Column}}</td><td>{{.Message}}</td><td>{{.Description}}</td><td>{{.Remediation}}{{range .References}}<br><a href="{{.}}">{{.}}</a>{{end}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>Проблем безопасности не обнаружено.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTMLReporter генерирует автономные HTML-отчеты
+type HTMLReporter struct{}
+
+// NewHTMLReporter создает новый HTML репортер
+func NewHTMLReporter() *HTMLReporter {
+	return &HTMLReporter{}
+}
+
+// htmlSeverityCount строка сводки по серьезности
+type htmlSeverityCount struct {
+	Severity Severity
+	Count    int
+}
+
+// htmlFile проблемы одного файла
+type htmlFile struct {
+	Path   string
+	Issues []Issue
+}
+
+// Generate реализует интерфейс Reporter
+func (r *HTMLReporter) Generate(issues []Issue) string {
+	sortIssues(issues)
+
+	// Подсчет проблем по серьезности
+	counts := countBySeverity(issues)
+	summary := make([]htmlSeverityCount, 0, len(counts))
+	for _, severity := range []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo} {
+		summary = append(summary, htmlSeverityCount{Severity: severity, Count: counts[severity]})
+	}
+
+	// Группировка проблем по файлам в порядке первого появления
+	var files []htmlFile
+	fileIndex := make(map[string]int)
+	for _, issue := range issues {
+		index, ok := fileIndex[issue.FilePath]
+		if !ok {
+			index = len(files)
+			fileIndex[issue.FilePath] = index
+			files = append(files, htmlFile{Path: issue.FilePath})
+		}
+		files[index].Issues = append(files[index].Issues, issue)
+	}
+
+	var builder strings.Builder
+	err := htmlTemplate.Execute(&builder, struct {
+		Timestamp string
+		Total     int
+		Summary   []htmlSeverityCount
+		Files     []htmlFile
+	}{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Total:     len(issues),
+		Summary:   summary,
+		Files:     files,
+	})
+	if err != nil {
+		return fmt.Sprintf("Ошибка генерации отчета в формате HTML: %v", err)
+	}
+
+	return builder.String()
+}
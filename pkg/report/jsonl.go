@@ -0,0 +1,34 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JSONLinesReporter генерирует отчеты в формате JSON Lines: одна проблема на строку
+type JSONLinesReporter struct{}
+
+// NewJSONLinesReporter создает новый JSON Lines репортер
+func NewJSONLinesReporter() *JSONLinesReporter {
+	return &JSONLinesReporter{}
+}
+
+// Generate реализует интерфейс Reporter
+func (r *JSONLinesReporter) Generate(issues []Issue) string {
+	sortIssues(issues)
+
+	var builder strings.Builder
+	for i, issue := range issues {
+		data, err := json.Marshal(issue)
+		if err != nil {
+			return fmt.Sprintf("Ошибка генерации отчета в формате JSON Lines: %v", err)
+		}
+		if i > 0 {
+			builder.WriteByte('\n')
+		}
+		builder.Write(data)
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,108 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// JUnitReporter генерирует отчеты в формате JUnit XML
+type JUnitReporter struct {
+	// Сведения о запуске, задаются через WithMetadata
+	meta *Metadata
+}
+
+// NewJUnitReporter создает новый JUnit репортер
+func NewJUnitReporter() *JUnitReporter {
+	return &JUnitReporter{}
+}
+
+// WithMetadata задает сведения о запуске: для каждого файла из AnalyzedFiles
+// создается набор тестов, в том числе пустой для файла без проблем
+func (r *JUnitReporter) WithMetadata(meta Metadata) *JUnitReporter {
+	r.meta = &meta
+	return r
+}
+
+// JUnitTestSuites корневой элемент JUnit-отчета
+type JUnitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite набор тестов для одного файла
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+}
+
+// JUnitTestCase тест, соответствующий одной проблеме
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+// JUnitFailure описание проблемы в тесте
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Generate реализует интерфейс Reporter
+func (r *JUnitReporter) Generate(issues []Issue) string {
+	sortIssues(issues)
+
+	suites := JUnitTestSuites{Tests: len(issues), Failures: len(issues)}
+
+	// Наборы проанализированных файлов создаются заранее, чтобы файлы без проблем не пропадали из отчета
+	suiteIndex := make(map[string]int)
+	if r.meta != nil {
+		for _, file := range r.meta.AnalyzedFiles {
+			if _, ok := suiteIndex[file]; ok {
+				continue
+			}
+			suiteIndex[file] = len(suites.TestSuites)
+			suites.TestSuites = append(suites.TestSuites, JUnitTestSuite{Name: file})
+		}
+	}
+
+	// Группировка остальных проблем по файлам в порядке первого появления
+	for _, issue := range issues {
+		index, ok := suiteIndex[issue.FilePath]
+		if !ok {
+			index = len(suites.TestSuites)
+			suiteIndex[issue.FilePath] = index
+			suites.TestSuites = append(suites.TestSuites, JUnitTestSuite{Name: issue.FilePath})
+		}
+
+		suite := &suites.TestSuites[index]
+		suite.Tests++
+		suite.Failures++
+		suite.TestCases = append(suite.TestCases, JUnitTestCase{
+			Name:      fmt.Sprintf("%s:%d:%d", issue.RuleID, issue.Line, issue.Column),
+			ClassName: issue.FilePath,
+			Failure: &JUnitFailure{
+				Message: issue.Message,
+				Type:    string(issue.Severity),
+				Text:    fmt.Sprintf("[%s] %s\n%s", issue.Severity, issue.Message, issue.Description),
+			},
+		})
+	}
+
+	// Пустой результат все равно должен быть корректным набором тестов
+	if len(suites.TestSuites) == 0 {
+		suites.TestSuites = []JUnitTestSuite{{Name: "go-audit"}}
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Ошибка генерации отчета в формате JUnit: %v", err)
+	}
+
+	return xml.Header + string(data)
+}
@@ -19,6 +19,69 @@ const (
 	SeverityInfo     Severity = "INFO"
 )
 
+// severityRank задает порядок серьезности: меньшее значение означает более серьезную проблему
+var severityRank = map[Severity]int{
+	SeverityCritical: 0,
+	SeverityHigh:     1,
+	SeverityMedium:   2,
+	SeverityLow:      3,
+	SeverityInfo:     4,
+}
+
+// AtLeast проверяет, что серьезность не ниже указанного порога
+func (s Severity) AtLeast(threshold Severity) bool {
+	rank, ok := severityRank[s]
+	if !ok {
+		return false
+	}
+	return rank <= severityRank[threshold]
+}
+
+// FilterBySeverity возвращает проблемы с серьезностью не ниже указанного порога
+func FilterBySeverity(issues []Issue, minSeverity Severity) []Issue {
+	var filtered []Issue
+	for _, issue := range issues {
+		if issue.Severity.AtLeast(minSeverity) {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// DefaultScoreWeights возвращает веса уровней серьезности для оценки риска по умолчанию
+func DefaultScoreWeights() map[Severity]int {
+	return map[Severity]int{
+		SeverityCritical: 10,
+		SeverityHigh:     5,
+		SeverityMedium:   2,
+		SeverityLow:      1,
+		SeverityInfo:     0,
+	}
+}
+
+// Score вычисляет оценку риска как сумму весов серьезности найденных проблем.
+// При weights == nil используются веса DefaultScoreWeights; уровни, отсутствующие в weights, не учитываются.
+func Score(issues []Issue, weights map[Severity]int) int {
+	if weights == nil {
+		weights = DefaultScoreWeights()
+	}
+
+	score := 0
+	for _, issue := range issues {
+		score += weights[issue.Severity]
+	}
+	return score
+}
+
+// ParseSeverity разбирает строковое значение уровня серьезности (регистр не учитывается)
+func ParseSeverity(value string) (Severity, error) {
+	switch severity := Severity(strings.ToUpper(strings.TrimSpace(value))); severity {
+	case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo:
+		return severity, nil
+	}
+	return "", fmt.Errorf("неизвестный уровень серьезности %q, допустимы CRITICAL, HIGH, MEDIUM, LOW, INFO", value)
+}
+
 // Issue представляет проблему безопасности, найденную правилом
 type Issue struct {
 	RuleID      string   `json:"ruleId"`
@@ -28,6 +91,30 @@ type Issue struct {
 	Column      int      `json:"column"`
 	Message     string   `json:"message"`
 	Description string   `json:"description"`
+	CWE         string   `json:"cwe,omitempty"`
+	// Позиция конца проблемы; совпадает с началом, если правило указывает одну точку
+	EndLine   int `json:"endLine,omitempty"`
+	EndColumn int `json:"endColumn,omitempty"`
+	// Рекомендация по исправлению и ссылки на описание проблемы (CWE, OWASP)
+	Remediation string   `json:"remediation,omitempty"`
+	References  []string `json:"references,omitempty"`
+	// Фрагмент исходного кода вокруг проблемы с номерами строк
+	Snippet string `json:"snippet,omitempty"`
+	// Стабильный отпечаток проблемы для сравнения с базовой линией
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Предлагаемое исправление, если правило может предложить замену текста
+	SuggestedFix *SuggestedFix `json:"suggestedFix,omitempty"`
+}
+
+// SuggestedFix описывает исправление проблемы заменой текста файла:
+// байты в диапазоне [StartOffset, EndOffset) заменяются на Replacement
+type SuggestedFix struct {
+	Description string `json:"description"`
+	StartOffset int    `json:"startOffset"`
+	EndOffset   int    `json:"endOffset"`
+	Replacement string `json:"replacement"`
+	// Исправление однозначно и может применяться автоматически через -apply-fixes
+	SafeToApply bool `json:"safeToApply"`
 }
 
 // Reporter интерфейс для различных форматов отчетов
@@ -36,17 +123,34 @@ type Reporter interface {
 }
 
 // TextReporter генерирует текстовые отчеты
-type TextReporter struct{}
+type TextReporter struct {
+	// Веса серьезности для оценки риска; nil — веса по умолчанию
+	weights map[Severity]int
+	// Сведения о запуске анализа; nil — отчет без итоговой строки о подавленных проблемах
+	meta *Metadata
+}
 
 // NewTextReporter создает новый текстовый репортер
 func NewTextReporter() *TextReporter {
 	return &TextReporter{}
 }
 
+// WithScoreWeights задает веса серьезности для оценки риска в сводке отчета
+func (r *TextReporter) WithScoreWeights(weights map[Severity]int) *TextReporter {
+	r.weights = weights
+	return r
+}
+
+// WithMetadata добавляет в конец отчета количество подавленных проблем
+func (r *TextReporter) WithMetadata(meta Metadata) *TextReporter {
+	r.meta = &meta
+	return r
+}
+
 // Generate реализует интерфейс Reporter
 func (r *TextReporter) Generate(issues []Issue) string {
 	if len(issues) == 0 {
-		return "Проблем безопасности не обнаружено."
+		return "Проблем безопасности не обнаружено." + r.footer()
 	}
 
 	var builder strings.Builder
@@ -60,20 +164,11 @@ func (r *TextReporter) Generate(issues []Issue) string {
 	// Заголовок
 	builder.WriteString("Go-audit - Отчет по анализу безопасности\n")
 	builder.WriteString(fmt.Sprintf("Дата: %s\n", time.Now().Format(time.RFC3339)))
-	builder.WriteString(fmt.Sprintf("Всего проблем: %d\n\n", len(issues)))
+	builder.WriteString(fmt.Sprintf("Всего проблем: %d\n", len(issues)))
+	builder.WriteString(fmt.Sprintf("Оценка риска: %d\n\n", Score(issues, r.weights)))
 
 	// Подсчет проблем по серьезности
-	severityCounts := map[Severity]int{
-		SeverityCritical: 0,
-		SeverityHigh:     0,
-		SeverityMedium:   0,
-		SeverityLow:      0,
-		SeverityInfo:     0,
-	}
-
-	for _, issue := range issues {
-		severityCounts[issue.Severity]++
-	}
+	severityCounts := countBySeverity(issues)
 
 	// Сводка по серьезности
 	builder.WriteString("Сводка по серьезности проблем:\n")
@@ -95,25 +190,90 @@ func (r *TextReporter) Generate(issues []Issue) string {
 			issue.Severity, issue.RuleID, issue.Line, issue.Column))
 		builder.WriteString(fmt.Sprintf("    %s\n", issue.Message))
 		builder.WriteString(fmt.Sprintf("    Правило: %s\n", issue.Description))
+		if issue.CWE != "" {
+			builder.WriteString(fmt.Sprintf("    CWE: %s\n", issue.CWE))
+		}
+		if issue.Remediation != "" {
+			builder.WriteString(fmt.Sprintf("    Рекомендация: %s\n", issue.Remediation))
+		}
+		if issue.Snippet != "" {
+			builder.WriteString("\n")
+			for _, line := range strings.Split(issue.Snippet, "\n") {
+				builder.WriteString(fmt.Sprintf("      %s\n", line))
+			}
+			builder.WriteString("\n")
+		}
 	}
 
+	builder.WriteString(r.footer())
 	return builder.String()
 }
 
+// footer возвращает итоговую строку отчета с количеством подавленных проблем
+func (r *TextReporter) footer() string {
+	if r.meta == nil {
+		return ""
+	}
+	return fmt.Sprintf("\nПодавлено проблем: %d\n", r.meta.Suppressed)
+}
+
 // JSONReporter генерирует отчеты в формате JSON
-type JSONReporter struct{}
+type JSONReporter struct {
+	// Веса серьезности для оценки риска; nil — веса по умолчанию
+	weights map[Severity]int
+	// Сведения о запуске анализа; nil — отчет без метаданных
+	meta *Metadata
+}
 
 // NewJSONReporter создает новый JSON репортер
 func NewJSONReporter() *JSONReporter {
 	return &JSONReporter{}
 }
 
+// WithScoreWeights задает веса серьезности для оценки риска в сводке отчета
+func (r *JSONReporter) WithScoreWeights(weights map[Severity]int) *JSONReporter {
+	r.weights = weights
+	return r
+}
+
+// WithMetadata добавляет в отчет сведения о запуске анализа
+func (r *JSONReporter) WithMetadata(meta Metadata) *JSONReporter {
+	r.meta = &meta
+	return r
+}
+
+// Metadata описывает запуск анализа, по результатам которого сформирован отчет
+type Metadata struct {
+	// Версия инструмента
+	ToolVersion string
+	// Длительность анализа
+	ScanDuration time.Duration
+	// Количество проанализированных файлов
+	ScannedFiles int
+	// Файлы, директории и шаблоны пакетов, переданные на анализ
+	TargetPaths []string
+	// Количество проблем, подавленных директивами, базовой линией и переопределениями для путей
+	Suppressed int
+	// Проанализированные файлы без исключенных конфигурацией и пропущенных из-за ошибок
+	AnalyzedFiles []string
+}
+
 // JSONReport представляет структуру JSON-отчета
 type JSONReport struct {
-	Timestamp   string         `json:"timestamp"`
+	Timestamp string `json:"timestamp"`
+	// Сведения о запуске анализа, заполняются через WithMetadata
+	ToolVersion    string   `json:"toolVersion,omitempty"`
+	ScanDurationMs int64    `json:"scanDurationMs,omitempty"`
+	ScannedFiles   int      `json:"scannedFiles,omitempty"`
+	TargetPaths    []string `json:"targetPaths,omitempty"`
+
 	TotalIssues int            `json:"totalIssues"`
 	Summary     map[string]int `json:"summary"`
-	Issues      []Issue        `json:"issues"`
+	// Количество подавленных проблем, не вошедших в отчет
+	Suppressed int `json:"suppressed"`
+	// Оценка риска: сумма весов серьезности проблем
+	Score  int     `json:"score"`
+	Issues []Issue `json:"issues"`
 }
 
 // Generate реализует интерфейс Reporter
@@ -137,8 +297,16 @@ func (r *JSONReporter) Generate(issues []Issue) string {
 		Timestamp:   time.Now().Format(time.RFC3339),
 		TotalIssues: len(issues),
 		Summary:     summary,
+		Score:       Score(issues, r.weights),
 		Issues:      issues,
 	}
+	if r.meta != nil {
+		report.ToolVersion = r.meta.ToolVersion
+		report.ScanDurationMs = r.meta.ScanDuration.Milliseconds()
+		report.ScannedFiles = r.meta.ScannedFiles
+		report.TargetPaths = r.meta.TargetPaths
+		report.Suppressed = r.meta.Suppressed
+	}
 
 	// Преобразование в JSON
 	jsonData, err := json.MarshalIndent(report, "", "  ")
@@ -149,21 +317,28 @@ func (r *JSONReporter) Generate(issues []Issue) string {
 	return string(jsonData)
 }
 
-// Вспомогательная функция для сортировки проблем
-func sortIssues(issues []Issue) {
-	// Порядок серьезности для сортировки
-	severityOrder := map[Severity]int{
+// countBySeverity подсчитывает количество проблем по уровням серьезности
+func countBySeverity(issues []Issue) map[Severity]int {
+	counts := map[Severity]int{
 		SeverityCritical: 0,
-		SeverityHigh:     1,
-		SeverityMedium:   2,
-		SeverityLow:      3,
-		SeverityInfo:     4,
+		SeverityHigh:     0,
+		SeverityMedium:   0,
+		SeverityLow:      0,
+		SeverityInfo:     0,
+	}
+
+	for _, issue := range issues {
+		counts[issue.Severity]++
 	}
+	return counts
+}
 
+// Вспомогательная функция для сортировки проблем
+func sortIssues(issues []Issue) {
 	// Сортировка по серьезности (более высокий приоритет сначала), затем по пути к файлу, затем по номеру строки
 	sort.Slice(issues, func(i, j int) bool {
 		if issues[i].Severity != issues[j].Severity {
-			return severityOrder[issues[i].Severity] < severityOrder[issues[j].Severity]
+			return severityRank[issues[i].Severity] < severityRank[issues[j].Severity]
 		}
 		if issues[i].FilePath != issues[j].FilePath {
 			return issues[i].FilePath < issues[j].FilePath
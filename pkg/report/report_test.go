@@ -1,9 +1,15 @@
 package report
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestTextReporterNoIssues проверяет генерацию текстового отчета без проблем
@@ -62,6 +68,10 @@ func TestTextReporterWithIssues(t *testing.T) {
 		t.Error("Отчет не содержит общее количество проблем")
 	}
 
+	if !strings.Contains(report, "Оценка риска: 17") {
+		t.Error("Отчет не содержит оценку риска")
+	}
+
 	if !strings.Contains(report, "КРИТИЧНЫЕ:  1") {
 		t.Error("Отчет не содержит количество критичных проблем")
 	}
@@ -95,6 +105,37 @@ func TestTextReporterWithIssues(t *testing.T) {
 	}
 }
 
+// TestTextReporterSnippet проверяет вывод фрагмента кода под проблемой
+func TestTextReporterSnippet(t *testing.T) {
+	issues := []Issue{
+		{
+			RuleID:   "SEC001",
+			Severity: SeverityHigh,
+			FilePath: "main.go",
+			Line:     6,
+			Message:  "Потенциальная SQL-инъекция",
+			Snippet:  "  5 | func query() {\n> 6 | \tdb.Query(q + name)\n  7 | }",
+		},
+	}
+
+	output := NewTextReporter().Generate(issues)
+	for _, line := range []string{"        5 | func query() {\n", "      > 6 | \tdb.Query(q + name)\n", "        7 | }\n"} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Отчет не содержит строку фрагмента %q:\n%s", line, output)
+		}
+	}
+
+	jsonOutput := NewJSONReporter().Generate(issues)
+	if !strings.Contains(jsonOutput, `"snippet": "  5 | func query() {\n\u003e 6 |`) {
+		t.Errorf("JSON-отчет не содержит фрагмент кода:\n%s", jsonOutput)
+	}
+
+	issues[0].Snippet = ""
+	if jsonOutput := NewJSONReporter().Generate(issues); strings.Contains(jsonOutput, `"snippet"`) {
+		t.Error("Пустой фрагмент не должен попадать в JSON-отчет")
+	}
+}
+
 // TestJSONReporterNoIssues проверяет генерацию JSON отчета без проблем
 func TestJSONReporterNoIssues(t *testing.T) {
 	reporter := NewJSONReporter()
@@ -196,6 +237,11 @@ func TestJSONReporterWithIssues(t *testing.T) {
 		t.Errorf("Summary[\"MEDIUM\"] = %d, ожидалось 1", jsonReport.Summary["MEDIUM"])
 	}
 
+	// Оценка риска с весами по умолчанию: 10 + 5 + 2
+	if jsonReport.Score != 17 {
+		t.Errorf("Score = %d, ожидалось 17", jsonReport.Score)
+	}
+
 	// Проверяем список проблем
 	if len(jsonReport.Issues) != 3 {
 		t.Errorf("len(Issues) = %d, ожидалось 3", len(jsonReport.Issues))
@@ -229,6 +275,533 @@ func TestJSONReporterWithIssues(t *testing.T) {
 	}
 }
 
+// TestJSONReporterMetadata проверяет сведения о запуске анализа в JSON-отчете
+func TestJSONReporterMetadata(t *testing.T) {
+	meta := Metadata{
+		ToolVersion:  "1.2.3",
+		ScanDuration: 1500 * time.Millisecond,
+		ScannedFiles: 12,
+		TargetPaths:  []string{"./...", "cmd/main.go"},
+		Suppressed:   3,
+	}
+	reportStr := NewJSONReporter().WithMetadata(meta).Generate([]Issue{})
+
+	var jsonReport JSONReport
+	if err := json.Unmarshal([]byte(reportStr), &jsonReport); err != nil {
+		t.Fatalf("Ошибка разбора JSON-отчета: %v", err)
+	}
+
+	if jsonReport.ToolVersion != "1.2.3" {
+		t.Errorf("ToolVersion = %q, ожидалось 1.2.3", jsonReport.ToolVersion)
+	}
+	if jsonReport.ScanDurationMs != 1500 {
+		t.Errorf("ScanDurationMs = %d, ожидалось 1500", jsonReport.ScanDurationMs)
+	}
+	if jsonReport.ScannedFiles != 12 {
+		t.Errorf("ScannedFiles = %d, ожидалось 12", jsonReport.ScannedFiles)
+	}
+	if !reflect.DeepEqual(jsonReport.TargetPaths, meta.TargetPaths) {
+		t.Errorf("TargetPaths = %v, ожидалось %v", jsonReport.TargetPaths, meta.TargetPaths)
+	}
+	if jsonReport.Suppressed != 3 {
+		t.Errorf("Suppressed = %d, ожидалось 3", jsonReport.Suppressed)
+	}
+
+	// Без метаданных поля не попадают в отчет
+	if plain := NewJSONReporter().Generate([]Issue{}); strings.Contains(plain, "toolVersion") {
+		t.Errorf("Отчет без WithMetadata не должен содержать метаданные:\n%s", plain)
+	}
+}
+
+// TestTextReporterSuppressed проверяет итоговую строку текстового отчета с количеством подавленных проблем
+func TestTextReporterSuppressed(t *testing.T) {
+	meta := Metadata{Suppressed: 2}
+
+	for name, issues := range map[string][]Issue{"with issues": sampleIssues(), "empty": {}} {
+		output := NewTextReporter().WithMetadata(meta).Generate(issues)
+		if !strings.HasSuffix(output, "Подавлено проблем: 2\n") {
+			t.Errorf("%s: отчет должен заканчиваться количеством подавленных проблем:\n%s", name, output)
+		}
+	}
+
+	// Без метаданных итоговая строка не выводится
+	if plain := NewTextReporter().Generate(sampleIssues()); strings.Contains(plain, "Подавлено проблем") {
+		t.Errorf("Отчет без WithMetadata не должен содержать количество подавленных проблем:\n%s", plain)
+	}
+}
+
+// sampleIssues возвращает набор проблем для тестирования форматов отчетов
+func sampleIssues() []Issue {
+	return []Issue{
+		{
+			RuleID:      "SEC001",
+			Severity:    SeverityHigh,
+			FilePath:    "main.go",
+			Line:        42,
+			Column:      10,
+			EndLine:     44,
+			EndColumn:   6,
+			Message:     "Потенциальная SQL-инъекция",
+			Description: "Обнаружена потенциальная SQL-инъекция",
+		},
+		{
+			RuleID:      "SEC002",
+			Severity:    SeverityCritical,
+			FilePath:    "main.go",
+			Line:        50,
+			Column:      5,
+			Message:     "Жёстко закодированный пароль",
+			Description: "Обнаружен жёстко закодированный пароль",
+		},
+		{
+			RuleID:      "SEC003",
+			Severity:    SeverityMedium,
+			FilePath:    "api/server.go",
+			Line:        30,
+			Column:      15,
+			Message:     "Небезопасная конфигурация HTTP",
+			Description: "Обнаружена небезопасная конфигурация HTTP",
+		},
+		{
+			RuleID:      "SEC009",
+			Severity:    SeverityInfo,
+			FilePath:    "api/server.go",
+			Line:        12,
+			Column:      2,
+			Message:     "Незавершенная доработка",
+			Description: "Незавершенная доработка безопасности в коде",
+		},
+	}
+}
+
+// TestSARIFReporter проверяет генерацию отчета в формате SARIF
+func TestSARIFReporter(t *testing.T) {
+	reporter := NewSARIFReporter(RuleInfo{
+		ID:          "SEC004",
+		Description: "Отсутствие проверок ошибок",
+		Remediation: "Проверяйте ошибки",
+		References:  []string{"https://cwe.mitre.org/data/definitions/252.html"},
+	})
+	output := reporter.Generate(sampleIssues())
+
+	var sarif SARIFLog
+	if err := json.Unmarshal([]byte(output), &sarif); err != nil {
+		t.Fatalf("Ошибка разбора SARIF-отчета: %v", err)
+	}
+
+	if sarif.Version != "2.1.0" || sarif.Schema == "" {
+		t.Errorf("Неверная версия или схема SARIF: %q, %q", sarif.Version, sarif.Schema)
+	}
+
+	if len(sarif.Runs) != 1 {
+		t.Fatalf("len(Runs) = %d, ожидалось 1", len(sarif.Runs))
+	}
+	run := sarif.Runs[0]
+
+	if run.Tool.Driver.Name == "" {
+		t.Error("Не указано имя инструмента")
+	}
+
+	// Метаданные правил: из проблем и переданного списка
+	descriptions := make(map[string]string)
+	for _, rule := range run.Tool.Driver.Rules {
+		if rule.ID == "" || rule.Name == "" {
+			t.Errorf("Правило без id или name: %+v", rule)
+		}
+		descriptions[rule.ID] = rule.ShortDescription.Text
+
+		// Рекомендация и ссылка передаются только для правил, в которых они указаны
+		switch rule.ID {
+		case "SEC004":
+			if rule.Help == nil || rule.Help.Text != "Проверяйте ошибки" || rule.HelpURI != "https://cwe.mitre.org/data/definitions/252.html" {
+				t.Errorf("Неверные help и helpUri правила SEC004: %+v, %q", rule.Help, rule.HelpURI)
+			}
+		case "SEC002":
+			if rule.Help != nil || rule.HelpURI != "" {
+				t.Errorf("У правила SEC002 без рекомендации указаны help или helpUri: %+v, %q", rule.Help, rule.HelpURI)
+			}
+		}
+	}
+	for _, id := range []string{"SEC001", "SEC002", "SEC003", "SEC004", "SEC009"} {
+		if descriptions[id] == "" {
+			t.Errorf("Нет описания правила %s в tool.driver.rules", id)
+		}
+	}
+
+	if len(run.Results) != 4 {
+		t.Fatalf("len(Results) = %d, ожидалось 4", len(run.Results))
+	}
+
+	levels := make(map[string]string)
+	for _, result := range run.Results {
+		levels[result.RuleID] = result.Level
+
+		if len(result.Locations) != 1 {
+			t.Fatalf("У результата %s %d расположений, ожидалось 1", result.RuleID, len(result.Locations))
+		}
+		location := result.Locations[0].PhysicalLocation
+		if location.ArtifactLocation.URI == "" || location.Region.StartLine == 0 || location.Region.StartColumn == 0 {
+			t.Errorf("Неполное расположение результата %s: %+v", result.RuleID, location)
+		}
+		// Конец региона указывается только для проблем с диапазоном позиций
+		if result.RuleID == "SEC001" && (location.Region.EndLine != 44 || location.Region.EndColumn != 6) {
+			t.Errorf("Конец региона SEC001 = %d:%d, ожидалось 44:6", location.Region.EndLine, location.Region.EndColumn)
+		}
+		if result.RuleID == "SEC002" && (location.Region.EndLine != 0 || location.Region.EndColumn != 0) {
+			t.Errorf("Конец региона SEC002 = %d:%d, ожидалось отсутствие", location.Region.EndLine, location.Region.EndColumn)
+		}
+	}
+
+	expectedLevels := map[string]string{
+		"SEC001": "error",
+		"SEC002": "error",
+		"SEC003": "warning",
+		"SEC009": "note",
+	}
+	for id, level := range expectedLevels {
+		if levels[id] != level {
+			t.Errorf("Уровень %s = %q, ожидалось %q", id, levels[id], level)
+		}
+	}
+}
+
+// TestHTMLReporter проверяет генерацию HTML-отчета и экранирование данных
+func TestHTMLReporter(t *testing.T) {
+	issues := append(sampleIssues(), Issue{
+		RuleID:      "SEC006",
+		Severity:    SeverityHigh,
+		FilePath:    "web/<handler>.go",
+		Line:        7,
+		Column:      3,
+		Message:     "Вывод <script>alert(1)</script> без экранирования",
+		Description: "Небезопасная обработка пользовательского ввода",
+		Remediation: "Экранируйте вывод через html/template",
+		References:  []string{"https://cwe.mitre.org/data/definitions/20.html"},
+	})
+
+	output := NewHTMLReporter().Generate(issues)
+
+	if !strings.Contains(output, "Экранируйте вывод через html/template") ||
+		!strings.Contains(output, `<a href="https://cwe.mitre.org/data/definitions/20.html">`) {
+		t.Error("HTML-отчет не содержит рекомендацию и ссылку")
+	}
+
+	if !strings.Contains(output, "<table>") {
+		t.Error("HTML-отчет не содержит таблицу")
+	}
+
+	// Сводка: 1 CRITICAL, 2 HIGH, 1 MEDIUM, 0 LOW, 1 INFO
+	for _, row := range []string{
+		`<td>CRITICAL</td><td>1</td>`,
+		`<td>HIGH</td><td>2</td>`,
+		`<td>MEDIUM</td><td>1</td>`,
+		`<td>LOW</td><td>0</td>`,
+		`<td>INFO</td><td>1</td>`,
+	} {
+		if !strings.Contains(output, row) {
+			t.Errorf("HTML-отчет не содержит строку сводки %s", row)
+		}
+	}
+
+	if strings.Contains(output, "<script>") {
+		t.Error("Сообщение с <script> не экранировано")
+	}
+	if !strings.Contains(output, "&lt;script&gt;") {
+		t.Error("В отчете нет экранированного сообщения")
+	}
+	if !strings.Contains(output, "web/&lt;handler&gt;.go") {
+		t.Error("Путь к файлу не экранирован")
+	}
+}
+
+// TestJUnitReporter проверяет генерацию отчета в формате JUnit XML
+func TestJUnitReporter(t *testing.T) {
+	issues := sampleIssues()
+	output := NewJUnitReporter().Generate(issues)
+
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal([]byte(output), &suites); err != nil {
+		t.Fatalf("Ошибка разбора JUnit-отчета: %v", err)
+	}
+
+	if len(suites.TestSuites) != 2 {
+		t.Fatalf("len(TestSuites) = %d, ожидалось 2 (по одному на файл)", len(suites.TestSuites))
+	}
+
+	total := 0
+	for _, suite := range suites.TestSuites {
+		if suite.Tests != len(suite.TestCases) {
+			t.Errorf("Набор %s: tests=%d, testcase=%d", suite.Name, suite.Tests, len(suite.TestCases))
+		}
+		for _, testCase := range suite.TestCases {
+			if testCase.Failure == nil || testCase.Failure.Message == "" || testCase.Failure.Type == "" {
+				t.Errorf("Тест %s без описания проблемы", testCase.Name)
+			}
+		}
+		total += len(suite.TestCases)
+	}
+
+	if total != len(issues) || suites.Tests != len(issues) {
+		t.Errorf("Тестов: %d (атрибут %d), ожидалось %d", total, suites.Tests, len(issues))
+	}
+
+	if name := suites.TestSuites[0].TestCases[0].Name; name != "SEC002:50:5" {
+		t.Errorf("Имя первого теста = %q, ожидалось SEC002:50:5", name)
+	}
+}
+
+// TestJUnitReporterNoIssues проверяет пустой JUnit-отчет
+func TestJUnitReporterNoIssues(t *testing.T) {
+	output := NewJUnitReporter().Generate([]Issue{})
+
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal([]byte(output), &suites); err != nil {
+		t.Fatalf("Ошибка разбора JUnit-отчета: %v", err)
+	}
+
+	if len(suites.TestSuites) != 1 || len(suites.TestSuites[0].TestCases) != 0 || suites.Tests != 0 {
+		t.Errorf("Ожидался один пустой набор тестов, получено %+v", suites)
+	}
+}
+
+// TestJUnitReporterAnalyzedFiles проверяет пустые наборы тестов для файлов без проблем
+func TestJUnitReporterAnalyzedFiles(t *testing.T) {
+	issues := sampleIssues()
+	files := []string{"clean.go"}
+	for _, issue := range issues {
+		files = append(files, issue.FilePath)
+	}
+	output := NewJUnitReporter().WithMetadata(Metadata{AnalyzedFiles: files}).Generate(issues)
+
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal([]byte(output), &suites); err != nil {
+		t.Fatalf("Ошибка разбора JUnit-отчета: %v", err)
+	}
+
+	if len(suites.TestSuites) != 3 {
+		t.Fatalf("len(TestSuites) = %d, ожидалось 3 (по одному на проанализированный файл)", len(suites.TestSuites))
+	}
+	if clean := suites.TestSuites[0]; clean.Name != "clean.go" || clean.Tests != 0 || len(clean.TestCases) != 0 {
+		t.Errorf("Ожидался пустой набор clean.go, получено %+v", clean)
+	}
+	if suites.Tests != len(issues) {
+		t.Errorf("tests = %d, ожидалось %d", suites.Tests, len(issues))
+	}
+
+	// Запуск без проблем содержит наборы файлов вместо синтетического набора
+	output = NewJUnitReporter().WithMetadata(Metadata{AnalyzedFiles: []string{"clean.go"}}).Generate(nil)
+	suites = JUnitTestSuites{}
+	if err := xml.Unmarshal([]byte(output), &suites); err != nil {
+		t.Fatalf("Ошибка разбора JUnit-отчета: %v", err)
+	}
+	if len(suites.TestSuites) != 1 || suites.TestSuites[0].Name != "clean.go" {
+		t.Errorf("Ожидался один набор clean.go, получено %+v", suites.TestSuites)
+	}
+}
+
+// TestJSONLinesReporter проверяет генерацию отчета в формате JSON Lines
+func TestJSONLinesReporter(t *testing.T) {
+	issues := sampleIssues()
+	output := NewJSONLinesReporter().Generate(issues)
+
+	lines := strings.Split(output, "\n")
+	if len(lines) != len(issues) {
+		t.Fatalf("Ожидалось %d строк, получено %d:\n%s", len(issues), len(lines), output)
+	}
+
+	for i, line := range lines {
+		var issue Issue
+		if err := json.Unmarshal([]byte(line), &issue); err != nil {
+			t.Fatalf("Строка %d не является корректным JSON: %v\n%s", i+1, err, line)
+		}
+		if issue.RuleID != issues[i].RuleID || issue.Line != issues[i].Line {
+			t.Errorf("Строка %d: получено %s:%d, ожидалось %s:%d", i+1, issue.RuleID, issue.Line, issues[i].RuleID, issues[i].Line)
+		}
+	}
+
+	// Проблемы отсортированы по серьезности
+	var first Issue
+	_ = json.Unmarshal([]byte(lines[0]), &first)
+	if first.Severity != SeverityCritical {
+		t.Errorf("Первой должна быть проблема CRITICAL, получено %s", first.Severity)
+	}
+
+	if output := NewJSONLinesReporter().Generate(nil); output != "" {
+		t.Errorf("Для пустого результата ожидался пустой вывод, получено %q", output)
+	}
+}
+
+// TestFixesReporter проверяет вывод предлагаемых исправлений
+func TestFixesReporter(t *testing.T) {
+	issues := []Issue{
+		{RuleID: "SEC024", Severity: SeverityInfo, FilePath: "b.go", Line: 7, Message: "ioutil.ReadAll устарела",
+			SuggestedFix: &SuggestedFix{Description: "Заменить на io.ReadAll", StartOffset: 90, EndOffset: 104, Replacement: "io.ReadAll"}},
+		{RuleID: "SEC001", Severity: SeverityCritical, FilePath: "a.go", Line: 3, Message: "SQL-инъекция"},
+		{RuleID: "SEC003", Severity: SeverityHigh, FilePath: "b.go", Line: 4, Message: "InsecureSkipVerify=true",
+			SuggestedFix: &SuggestedFix{StartOffset: 40, EndOffset: 44, Replacement: "false"}},
+	}
+
+	var fixesReport FixesReport
+	if err := json.Unmarshal([]byte(NewFixesReporter().Generate(issues)), &fixesReport); err != nil {
+		t.Fatalf("Ошибка разбора отчета исправлений: %v", err)
+	}
+
+	if len(fixesReport.Fixes) != 2 {
+		t.Fatalf("Ожидалось 2 исправления, получено %d: %+v", len(fixesReport.Fixes), fixesReport.Fixes)
+	}
+	// Исправления упорядочены по смещению в файле
+	first, second := fixesReport.Fixes[0], fixesReport.Fixes[1]
+	if first.RuleID != "SEC003" || first.StartOffset != 40 || first.EndOffset != 44 || first.Replacement != "false" {
+		t.Errorf("Первое исправление = %+v, ожидалось SEC003 [40, 44) -> false", first)
+	}
+	if second.RuleID != "SEC024" || second.Replacement != "io.ReadAll" || second.Line != 7 {
+		t.Errorf("Второе исправление = %+v, ожидалось SEC024 -> io.ReadAll", second)
+	}
+
+	if output := NewFixesReporter().Generate(nil); !strings.Contains(output, `"fixes": []`) {
+		t.Errorf("Для результата без исправлений ожидался пустой список, получено %s", output)
+	}
+}
+
+// TestCSVReporter проверяет генерацию отчета в формате CSV
+func TestCSVReporter(t *testing.T) {
+	issues := append(sampleIssues(), Issue{
+		RuleID:   "SEC001",
+		Severity: SeverityLow,
+		FilePath: "db.go",
+		Line:     3,
+		Column:   1,
+		Message:  `Запрос "SELECT a, b" собран конкатенацией`,
+	})
+
+	output := NewCSVReporter().Generate(issues)
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("Ошибка разбора CSV-отчета: %v", err)
+	}
+
+	if len(records) != len(issues)+1 {
+		t.Fatalf("Строк в CSV: %d, ожидалось %d", len(records), len(issues)+1)
+	}
+
+	if header := strings.Join(records[0], ","); header != "severity,ruleId,file,line,column,message" {
+		t.Errorf("Заголовок = %q", header)
+	}
+
+	// Первая строка после сортировки: CRITICAL SEC002
+	expectedFirst := []string{"CRITICAL", "SEC002", "main.go", "50", "5", "Жёстко закодированный пароль"}
+	if strings.Join(records[1], "|") != strings.Join(expectedFirst, "|") {
+		t.Errorf("Первая строка = %v, ожидалось %v", records[1], expectedFirst)
+	}
+
+	// Сообщение с запятыми и кавычками должно сохраниться без изменений
+	found := false
+	for _, record := range records[1:] {
+		if record[2] == "db.go" {
+			found = true
+			if record[5] != `Запрос "SELECT a, b" собран конкатенацией` {
+				t.Errorf("Сообщение искажено: %q", record[5])
+			}
+		}
+	}
+	if !found {
+		t.Error("В CSV нет строки для db.go")
+	}
+}
+
+// TestFilterBySeverity проверяет фильтрацию проблем на границе порога серьезности
+func TestFilterBySeverity(t *testing.T) {
+	testCases := []struct {
+		threshold Severity
+		expected  []string
+	}{
+		{threshold: SeverityCritical, expected: []string{"SEC002"}},
+		{threshold: SeverityHigh, expected: []string{"SEC001", "SEC002"}},
+		{threshold: SeverityMedium, expected: []string{"SEC001", "SEC002", "SEC003"}},
+		{threshold: SeverityInfo, expected: []string{"SEC001", "SEC002", "SEC003", "SEC009"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.threshold), func(t *testing.T) {
+			var ids []string
+			for _, issue := range FilterBySeverity(sampleIssues(), tc.threshold) {
+				ids = append(ids, issue.RuleID)
+			}
+
+			if strings.Join(ids, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("FilterBySeverity(%s) = %v, ожидалось %v", tc.threshold, ids, tc.expected)
+			}
+		})
+	}
+}
+
+// TestScore проверяет вычисление оценки риска по весам серьезности
+func TestScore(t *testing.T) {
+	testCases := []struct {
+		name     string
+		issues   []Issue
+		weights  map[Severity]int
+		expected int
+	}{
+		{
+			name:     "no issues",
+			issues:   nil,
+			expected: 0,
+		},
+		{
+			name:     "default weights",
+			issues:   sampleIssues(),
+			expected: 5 + 10 + 2 + 0,
+		},
+		{
+			name: "every severity with default weights",
+			issues: []Issue{
+				{Severity: SeverityCritical},
+				{Severity: SeverityCritical},
+				{Severity: SeverityHigh},
+				{Severity: SeverityMedium},
+				{Severity: SeverityLow},
+				{Severity: SeverityInfo},
+			},
+			expected: 2*10 + 5 + 2 + 1 + 0,
+		},
+		{
+			name:     "custom weights",
+			issues:   sampleIssues(),
+			weights:  map[Severity]int{SeverityCritical: 100, SeverityHigh: 20, SeverityMedium: 3, SeverityInfo: 1},
+			expected: 20 + 100 + 3 + 1,
+		},
+		{
+			name:     "severity missing from weights",
+			issues:   sampleIssues(),
+			weights:  map[Severity]int{SeverityCritical: 7},
+			expected: 7,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if score := Score(tc.issues, tc.weights); score != tc.expected {
+				t.Errorf("Score = %d, ожидалось %d", score, tc.expected)
+			}
+		})
+	}
+
+	// Настроенные веса передаются в текстовый и JSON-отчеты
+	weights := map[Severity]int{SeverityCritical: 1}
+	if text := NewTextReporter().WithScoreWeights(weights).Generate(sampleIssues()); !strings.Contains(text, "Оценка риска: 1\n") {
+		t.Errorf("Текстовый отчет не содержит оценку с настроенными весами:\n%s", text)
+	}
+	var jsonReport JSONReport
+	if err := json.Unmarshal([]byte(NewJSONReporter().WithScoreWeights(weights).Generate(sampleIssues())), &jsonReport); err != nil {
+		t.Fatalf("Ошибка разбора JSON-отчета: %v", err)
+	}
+	if jsonReport.Score != 1 {
+		t.Errorf("Score в JSON-отчете = %d, ожидалось 1", jsonReport.Score)
+	}
+}
+
 // TestSortIssues проверяет сортировку проблем
 func TestSortIssues(t *testing.T) {
 	issues := []Issue{
@@ -370,3 +943,84 @@ func TestSeverityTypes(t *testing.T) {
 		t.Errorf("SeverityInfo = %s, ожидалось INFO", SeverityInfo)
 	}
 }
+
+// TestParseSeverity проверяет разбор уровней серьезности
+func TestParseSeverity(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected Severity
+		wantErr  bool
+	}{
+		{value: "CRITICAL", expected: SeverityCritical},
+		{value: "medium", expected: SeverityMedium},
+		{value: " Info ", expected: SeverityInfo},
+		{value: "SEVERE", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		severity, err := ParseSeverity(tc.value)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParseSeverity(%q) ошибка = %v, ожидалась ошибка: %v", tc.value, err, tc.wantErr)
+			continue
+		}
+		if severity != tc.expected {
+			t.Errorf("ParseSeverity(%q) = %s, ожидалось %s", tc.value, severity, tc.expected)
+		}
+	}
+}
+
+// TestBaseline проверяет запись, загрузку и применение базовой линии
+func TestBaseline(t *testing.T) {
+	issue := Issue{RuleID: "SEC001", FilePath: "main.go", Line: 10, Message: "sql"}
+	issue.Fingerprint = Fingerprint(issue, "\tdb.Exec(query + name)")
+
+	// Перемещенная строка с другим отступом дает тот же отпечаток
+	moved := Issue{RuleID: "SEC001", FilePath: "main.go", Line: 25, Message: "sql"}
+	moved.Fingerprint = Fingerprint(moved, "    db.Exec(query  +  name)   ")
+	if moved.Fingerprint != issue.Fingerprint {
+		t.Fatal("Отпечаток не должен зависеть от номера строки и пробелов")
+	}
+
+	other := Issue{RuleID: "SEC001", FilePath: "main.go", Line: 10, Message: "sql"}
+	other.Fingerprint = Fingerprint(other, "db.Exec(otherQuery + name)")
+	if other.Fingerprint == issue.Fingerprint {
+		t.Fatal("Разные строки должны давать разные отпечатки")
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := WriteBaseline(path, []Issue{issue}); err != nil {
+		t.Fatalf("Ошибка записи базовой линии: %v", err)
+	}
+
+	baseline, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("Ошибка загрузки базовой линии: %v", err)
+	}
+
+	// Одна запись скрывает только одну из двух одинаковых проблем
+	kept, matched := baseline.Filter([]Issue{moved, issue, other})
+	if len(matched) != 1 {
+		t.Errorf("Ожидалась 1 известная проблема, получено %d", len(matched))
+	}
+	if len(kept) != 2 || kept[0].Line != 10 || kept[1].Fingerprint != other.Fingerprint {
+		t.Errorf("Неверный набор новых проблем: %+v", kept)
+	}
+}
+
+// TestLoadBaselineInvalid проверяет ошибки загрузки базовой линии
+func TestLoadBaselineInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := LoadBaseline(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Ожидалась ошибка для отсутствующего файла")
+	}
+
+	path := filepath.Join(dir, "baseline.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "issues": []}`), 0644); err != nil {
+		t.Fatalf("Ошибка записи файла: %v", err)
+	}
+	if _, err := LoadBaseline(path); err == nil {
+		t.Error("Ожидалась ошибка для неподдерживаемой версии")
+	}
+}
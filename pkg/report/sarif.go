@@ -0,0 +1,203 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// sarifVersion версия формата SARIF
+const sarifVersion = "2.1.0"
+
+// sarifSchema адрес JSON-схемы SARIF 2.1.0
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// RuleInfo содержит метаданные правила для форматов отчетов, описывающих набор правил
+type RuleInfo struct {
+	ID          string
+	Description string
+	Remediation string
+	References  []string
+}
+
+// SARIFReporter генерирует отчеты в формате SARIF 2.1.0
+type SARIFReporter struct {
+	rules []RuleInfo
+}
+
+// NewSARIFReporter создает новый SARIF репортер.
+// Метаданные правил без найденных проблем можно передать явно,
+// для остальных правил они берутся из самих проблем.
+func NewSARIFReporter(rules ...RuleInfo) *SARIFReporter {
+	return &SARIFReporter{rules: rules}
+}
+
+// SARIFLog корневой объект SARIF-отчета
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun описывает один запуск инструмента анализа
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool описывает инструмент анализа
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver содержит сведения об инструменте и его правилах
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule описывает правило анализа
+type SARIFRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription SARIFMessage `json:"shortDescription"`
+	// Рекомендация по исправлению и ссылка на описание проблемы
+	Help    *SARIFMessage `json:"help,omitempty"`
+	HelpURI string        `json:"helpUri,omitempty"`
+}
+
+// SARIFMessage текстовое сообщение SARIF
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult описывает найденную проблему
+type SARIFResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SARIFMessage    `json:"message"`
+	Locations []SARIFLocation `json:"locations"`
+}
+
+// SARIFLocation описывает расположение проблемы
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation описывает расположение проблемы в файле
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           SARIFRegion           `json:"region"`
+}
+
+// SARIFArtifactLocation указывает файл с проблемой
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion указывает позицию проблемы в файле
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// Generate реализует интерфейс Reporter
+func (r *SARIFReporter) Generate(issues []Issue) string {
+	sortIssues(issues)
+
+	results := make([]SARIFResult, 0, len(issues))
+	for _, issue := range issues {
+		results = append(results, SARIFResult{
+			RuleID:  issue.RuleID,
+			Level:   sarifLevel(issue.Severity),
+			Message: SARIFMessage{Text: issue.Message},
+			Locations: []SARIFLocation{{
+				PhysicalLocation: SARIFPhysicalLocation{
+					ArtifactLocation: SARIFArtifactLocation{URI: filepath.ToSlash(issue.FilePath)},
+					Region: SARIFRegion{
+						StartLine:   issue.Line,
+						StartColumn: issue.Column,
+						EndLine:     issue.EndLine,
+						EndColumn:   issue.EndColumn,
+					},
+				},
+			}},
+		})
+	}
+
+	log := SARIFLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []SARIFRun{{
+			Tool: SARIFTool{
+				Driver: SARIFDriver{
+					Name:           "go-audit",
+					InformationURI: "https://github.com/podushkina/go-audit",
+					Rules:          r.sarifRules(issues),
+				},
+			},
+			Results: results,
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Ошибка генерации отчета в формате SARIF: %v", err)
+	}
+
+	return string(data)
+}
+
+// sarifRules собирает метаданные правил из переданного списка и найденных проблем
+func (r *SARIFReporter) sarifRules(issues []Issue) []SARIFRule {
+	infos := make(map[string]RuleInfo)
+	for _, rule := range r.rules {
+		infos[rule.ID] = rule
+	}
+	for _, issue := range issues {
+		if _, ok := infos[issue.RuleID]; !ok {
+			infos[issue.RuleID] = RuleInfo{
+				ID:          issue.RuleID,
+				Description: issue.Description,
+				Remediation: issue.Remediation,
+				References:  issue.References,
+			}
+		}
+	}
+
+	rules := make([]SARIFRule, 0, len(infos))
+	for id, info := range infos {
+		rule := SARIFRule{
+			ID:               id,
+			Name:             id,
+			ShortDescription: SARIFMessage{Text: info.Description},
+		}
+		if info.Remediation != "" {
+			rule.Help = &SARIFMessage{Text: info.Remediation}
+		}
+		if len(info.References) > 0 {
+			rule.HelpURI = info.References[0]
+		}
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+	return rules
+}
+
+// sarifLevel преобразует уровень серьезности в уровень SARIF
+func sarifLevel(severity Severity) string {
+	switch severity {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}